@@ -1,11 +1,12 @@
 package hyperliquid
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 )
 
-// API implementation general error
+// API implementation general error.
+// It is used for errors detected by the client itself, see errors.go for the errors returned by the API.
 type APIError struct {
 	Message string
 }
@@ -17,50 +18,62 @@ func (e APIError) Error() string {
 // IAPIService is an interface for making requests to the API Service.
 //
 // It has a Request method that takes a path and a payload and returns a byte array and an error.
+// It has a RequestWithContext method that does the same but honours the cancellation of ctx.
 // It has a debug method that takes a format string and args and returns nothing.
 // It has an Endpoint method that returns a string.
 type IAPIService interface {
 	debug(format string, args ...any)
 	Request(path string, payload any) ([]byte, error)
+	RequestWithContext(ctx context.Context, path string, payload any) ([]byte, error)
 	Endpoint() string
 	KeyManager() *PKeyManager
+	Signer() Signer
 }
 
 // MakeUniversalRequest is a generic function that takes an
 // IAPIService and a request and returns a pointer to the result and an error.
 // It makes a request to the API Service and unmarshals the result into the result type T
 func MakeUniversalRequest[T any](api IAPIService, request any) (*T, error) {
-	if api.Endpoint() == "" {
-		return nil, APIError{Message: "Endpoint not set"}
-	}
+	return MakeUniversalRequestWithContext[T](context.Background(), api, request)
+}
+
+// MakeUniversalRequestWithContext is the same as MakeUniversalRequest
+// but the request is bound to ctx and is aborted when ctx is done.
+func MakeUniversalRequestWithContext[T any](ctx context.Context, api IAPIService, request any) (*T, error) {
 	if api == nil {
 		return nil, APIError{Message: "API not set"}
 	}
-	if api.Endpoint() == "/exchange" && api.KeyManager() == nil {
+	if api.Endpoint() == "" {
+		return nil, APIError{Message: "Endpoint not set"}
+	}
+	if api.Endpoint() == "/exchange" && api.Signer() == nil {
 		return nil, APIError{Message: "API key not set"}
 	}
+	return sendUniversalRequest[T](ctx, api, request)
+}
 
-	response, err := api.Request(api.Endpoint(), request)
+// sendUniversalRequest sends the request and decodes the response without checking
+// that a signer is set, e.g. for requests signed by an external signer.
+func sendUniversalRequest[T any](ctx context.Context, api IAPIService, request any) (*T, error) {
+	response, err := api.RequestWithContext(ctx, api.Endpoint(), request)
 	if err != nil {
 		return nil, err
 	}
 
-	var result T
-	err = json.Unmarshal(response, &result)
-	if err == nil {
-		return &result, nil
+	// Rejections have the same shape on every endpoint: {"status": "err", "response": "message"}
+	var errResult struct {
+		Status   string          `json:"status"`
+		Response json.RawMessage `json:"response"`
 	}
-
-	var errResult map[string]any
-	err = json.Unmarshal(response, &errResult)
-	if err != nil {
-		api.debug("Error second json.Unmarshal: %s", err)
-		return nil, APIError{Message: "Unexpected response"}
+	if json.Unmarshal(response, &errResult) == nil && errResult.Status == "err" {
+		return nil, newExchangeError(errResult.Response)
 	}
 
-	if errResult["status"] == "err" {
-		return nil, APIError{Message: errResult["response"].(string)}
+	var result T
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		api.debug("Error json.Unmarshal: %s", err)
+		return nil, &DecodeError{Body: response, Err: err}
 	}
-
-	return nil, APIError{Message: fmt.Sprintf("Unexpected response: %v", errResult)}
+	return &result, nil
 }
@@ -0,0 +1,665 @@
+package hyperliquid
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownAsset is returned when a coin or a spot token is not found in the market metadata.
+var ErrUnknownAsset = errors.New("unknown asset")
+
+// MetaSnapshot is a serializable copy of the perp and spot asset metadata.
+// Store it (e.g. as JSON) and seed a new client with LoadMetaSnapshot
+// to start up without reaching the /info endpoint.
+// Meta and SpotMeta are the name maps of BuildMetaMap and BuildSpotMetaMap,
+// Assets holds every market with the perp dexes and is used first when it is set.
+type MetaSnapshot struct {
+	Meta       map[string]AssetInfo     `json:"meta"`
+	SpotMeta   map[string]AssetInfo     `json:"spotMeta"`
+	SpotTokens map[string]SpotTokenInfo `json:"spotTokens,omitempty"`
+	Assets     []ResolvedAsset          `json:"assets,omitempty"`
+}
+
+// AssetResolver maps the symbols of the perp, spot and HIP-3 perp dex markets to their assets.
+// A symbol is a perp coin ("BTC"), a HIP-3 coin prefixed with its dex ("xyz:XYZ100"),
+// a spot market ("PURR/USDC", "@107", or the pair of token names "HYPE/USDC")
+// or, for spot only, a token name ("HYPE") resolved to its market against USDC.
+//
+// The metadata is fetched on first use and kept until Refresh or Load replace it,
+// run RunRefresh to pick up the new listings. One resolver is shared by the APIs of a client, see SetAssetResolver.
+type AssetResolver struct {
+	info        *InfoAPI
+	refreshMu   sync.Mutex // serializes the fetches
+	lastRefresh time.Time  // time of the last fetch, guarded by refreshMu
+	mu          sync.RWMutex
+	table       *assetTable
+	listeners   []AssetListener
+}
+
+// AssetListener receives the changes of the markets found by Refresh, nil callbacks are skipped.
+// A market is identified by its asset id. The callbacks run on the refreshing goroutine
+// once the new metadata is in use, so they can resolve the new assets.
+type AssetListener struct {
+	OnListed   func(asset ResolvedAsset)
+	OnDelisted func(asset ResolvedAsset)
+	// OnChanged is called when SzDecimals or MaxLeverage change.
+	OnChanged func(previous ResolvedAsset, asset ResolvedAsset)
+}
+
+// WithLegacyAssetFallback makes the exchange actions resolve the coins missing from the metadata
+// to the first asset of the market, BTC for perps, as the name maps did.
+// Without it they fail with ErrUnknownAsset. Only use it for code that relies on the old behaviour:
+// an order on a coin missing from the metadata is sent for the wrong coin.
+func WithLegacyAssetFallback() ClientOption {
+	return func(client *Client) {
+		client.legacyAssets = true
+	}
+}
+
+// NewAssetResolver returns an AssetResolver that fetches the metadata with info.
+func NewAssetResolver(info *InfoAPI) *AssetResolver {
+	return &AssetResolver{info: info}
+}
+
+// Refresh fetches the perp, perp dex and spot metadata from the /info endpoint and replaces the cached copy.
+// The listeners are told about the changes if a copy was already loaded.
+func (resolver *AssetResolver) Refresh(ctx context.Context) error {
+	return resolver.refresh(ctx, 0)
+}
+
+// refresh is Refresh, skipped if the metadata was fetched less than minInterval ago.
+// The calls made while a refresh is in flight wait for it and, with a minInterval, skip their own fetch.
+func (resolver *AssetResolver) refresh(ctx context.Context, minInterval time.Duration) error {
+	resolver.refreshMu.Lock()
+	if minInterval > 0 && time.Since(resolver.lastRefresh) < minInterval {
+		resolver.refreshMu.Unlock()
+		return nil
+	}
+	table, err := resolver.fetch(ctx)
+	if err != nil {
+		resolver.refreshMu.Unlock()
+		return err
+	}
+	previous := resolver.get()
+	resolver.set(table)
+	resolver.refreshMu.Unlock()
+
+	if previous != nil {
+		resolver.notify(previous, table)
+	}
+	return nil
+}
+
+// RunRefresh calls Refresh every interval until ctx is done.
+// Errors are reported to onError, which can be nil. Run it in its own goroutine.
+func (resolver *AssetResolver) RunRefresh(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := resolver.Refresh(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// AddListener registers callbacks for the listings, delistings and changes found by Refresh.
+func (resolver *AssetResolver) AddListener(listener AssetListener) {
+	resolver.mu.Lock()
+	defer resolver.mu.Unlock()
+	resolver.listeners = append(resolver.listeners, listener)
+}
+
+// assetChanges are the differences between two tables.
+type assetChanges struct {
+	listed   []ResolvedAsset
+	delisted []ResolvedAsset
+	changed  [][2]ResolvedAsset // previous and new asset
+}
+
+// diffAssets compares the markets of two tables by asset id.
+// A market is delisted when it disappears or is flagged as delisted, and listed again when the flag is cleared.
+// MaxLeverage is not compared when it was unknown, e.g. after loading a snapshot of name maps.
+func diffAssets(previous *assetTable, current *assetTable) assetChanges {
+	var changes assetChanges
+	for _, asset := range current.assets {
+		i, ok := previous.ids[asset.AssetId]
+		if !ok {
+			if !asset.IsDelisted {
+				changes.listed = append(changes.listed, asset)
+			}
+			continue
+		}
+		old := previous.assets[i]
+		switch {
+		case old.IsDelisted && !asset.IsDelisted:
+			changes.listed = append(changes.listed, asset)
+		case !old.IsDelisted && asset.IsDelisted:
+			changes.delisted = append(changes.delisted, asset)
+		case old.SzDecimals != asset.SzDecimals || (old.MaxLeverage != 0 && old.MaxLeverage != asset.MaxLeverage):
+			changes.changed = append(changes.changed, [2]ResolvedAsset{old, asset})
+		}
+	}
+	for _, asset := range previous.assets {
+		if _, ok := current.ids[asset.AssetId]; !ok && !asset.IsDelisted {
+			changes.delisted = append(changes.delisted, asset)
+		}
+	}
+	return changes
+}
+
+// notify calls the listeners with the differences between two tables.
+func (resolver *AssetResolver) notify(previous *assetTable, current *assetTable) {
+	resolver.mu.RLock()
+	listeners := slices.Clone(resolver.listeners)
+	resolver.mu.RUnlock()
+	if len(listeners) == 0 {
+		return
+	}
+	changes := diffAssets(previous, current)
+	for _, listener := range listeners {
+		for _, asset := range changes.listed {
+			if listener.OnListed != nil {
+				listener.OnListed(asset)
+			}
+		}
+		for _, asset := range changes.delisted {
+			if listener.OnDelisted != nil {
+				listener.OnDelisted(asset)
+			}
+		}
+		for _, change := range changes.changed {
+			if listener.OnChanged != nil {
+				listener.OnChanged(change[0], change[1])
+			}
+		}
+	}
+}
+
+// Load replaces the cached metadata with a snapshot, see MetaSnapshot.
+func (resolver *AssetResolver) Load(snapshot MetaSnapshot) {
+	if len(snapshot.Assets) > 0 {
+		resolver.set(newAssetTable(snapshot.Assets, maps.Clone(snapshot.SpotTokens)))
+		return
+	}
+	resolver.set(legacyAssetTable(snapshot))
+}
+
+// Snapshot returns a copy of the cached metadata. The maps are nil if nothing is loaded yet.
+func (resolver *AssetResolver) Snapshot() MetaSnapshot {
+	table := resolver.get()
+	if table == nil {
+		return MetaSnapshot{}
+	}
+	meta, spotMeta := table.legacyMaps()
+	return MetaSnapshot{
+		Meta:       meta,
+		SpotMeta:   spotMeta,
+		SpotTokens: maps.Clone(table.tokens),
+		Assets:     slices.Clone(table.assets),
+	}
+}
+
+// Assets returns the cached markets sorted by asset id, without fetching the metadata.
+func (resolver *AssetResolver) Assets() []ResolvedAsset {
+	table := resolver.get()
+	if table == nil {
+		return nil
+	}
+	return slices.Clone(table.assets)
+}
+
+// Resolve returns the market of symbol, looking up the perps first and then the spot markets.
+// Use the spot market name ("@107") for the spot tokens that share their name with a perp.
+func (resolver *AssetResolver) Resolve(ctx context.Context, symbol string) (ResolvedAsset, error) {
+	table, err := resolver.load(ctx)
+	if err != nil {
+		return ResolvedAsset{}, err
+	}
+	if asset, ok := table.perp(symbol); ok {
+		return asset, nil
+	}
+	if asset, ok := table.spot(symbol); ok {
+		return asset, nil
+	}
+	return ResolvedAsset{}, fmt.Errorf("%w: %s", ErrUnknownAsset, symbol)
+}
+
+// resolveLoaded is Resolve without fetching the metadata: ok is false if it is not loaded yet or symbol is unknown.
+func (resolver *AssetResolver) resolveLoaded(symbol string) (ResolvedAsset, bool) {
+	table := resolver.get()
+	if table == nil {
+		return ResolvedAsset{}, false
+	}
+	if asset, ok := table.perp(symbol); ok {
+		return asset, true
+	}
+	return table.spot(symbol)
+}
+
+// ResolvePerp returns the perp market of symbol, e.g. "BTC" or "xyz:XYZ100".
+func (resolver *AssetResolver) ResolvePerp(ctx context.Context, symbol string) (ResolvedAsset, error) {
+	table, err := resolver.load(ctx)
+	if err != nil {
+		return ResolvedAsset{}, err
+	}
+	if asset, ok := table.perp(symbol); ok {
+		return asset, nil
+	}
+	return ResolvedAsset{}, fmt.Errorf("%w: perp %s", ErrUnknownAsset, symbol)
+}
+
+// ResolveSpot returns the spot market of symbol, e.g. "PURR/USDC", "@107", "HYPE/USDC" or "HYPE".
+func (resolver *AssetResolver) ResolveSpot(ctx context.Context, symbol string) (ResolvedAsset, error) {
+	table, err := resolver.load(ctx)
+	if err != nil {
+		return ResolvedAsset{}, err
+	}
+	if asset, ok := table.spot(symbol); ok {
+		return asset, nil
+	}
+	return ResolvedAsset{}, fmt.Errorf("%w: spot %s", ErrUnknownAsset, symbol)
+}
+
+// ResolveAssetId returns the market of an asset id as used in actions, e.g. 10107 for "@107".
+func (resolver *AssetResolver) ResolveAssetId(ctx context.Context, assetId int) (ResolvedAsset, error) {
+	table, err := resolver.load(ctx)
+	if err != nil {
+		return ResolvedAsset{}, err
+	}
+	if i, ok := table.ids[assetId]; ok {
+		return table.assets[i], nil
+	}
+	return ResolvedAsset{}, fmt.Errorf("%w: asset id %d", ErrUnknownAsset, assetId)
+}
+
+// SpotToken returns the spot token by name, e.g. "PURR" or "USDC".
+// The wire form "PURR:0xc1fb593aeffbeb02f85e0308e9956a90" is accepted as well.
+func (resolver *AssetResolver) SpotToken(ctx context.Context, token string) (SpotTokenInfo, error) {
+	table, err := resolver.load(ctx)
+	if err != nil {
+		return SpotTokenInfo{}, err
+	}
+	name, tokenID, hasID := strings.Cut(token, ":")
+	info, ok := table.tokens[name]
+	if !ok || (hasID && !strings.EqualFold(tokenID, info.TokenID)) {
+		return SpotTokenInfo{}, fmt.Errorf("%w: spot token %s", ErrUnknownAsset, token)
+	}
+	return info, nil
+}
+
+func (resolver *AssetResolver) get() *assetTable {
+	resolver.mu.RLock()
+	defer resolver.mu.RUnlock()
+	return resolver.table
+}
+
+func (resolver *AssetResolver) set(table *assetTable) {
+	resolver.mu.Lock()
+	defer resolver.mu.Unlock()
+	resolver.table = table
+}
+
+// load returns the cached metadata and fetches it if it is not loaded yet.
+func (resolver *AssetResolver) load(ctx context.Context) (*assetTable, error) {
+	if table := resolver.get(); table != nil {
+		return table, nil
+	}
+	resolver.refreshMu.Lock()
+	defer resolver.refreshMu.Unlock()
+	if table := resolver.get(); table != nil {
+		return table, nil
+	}
+	table, err := resolver.fetch(ctx)
+	if err != nil {
+		resolver.info.debug("Error loading meta: %s", err)
+		return nil, err
+	}
+	resolver.set(table)
+	return table, nil
+}
+
+// fetch requests the metadata of the first perp dex, of every HIP-3 perp dex and of the spot markets.
+// It records the time of the attempt, successful or not, and must be called with refreshMu held.
+func (resolver *AssetResolver) fetch(ctx context.Context) (*assetTable, error) {
+	resolver.lastRefresh = time.Now()
+	meta, err := resolver.info.GetMetaWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assets := perpAssets(meta, "", 0)
+	dexs, err := resolver.info.GetPerpDexsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for index, dex := range *dexs {
+		if index == 0 || dex.Name == "" {
+			continue
+		}
+		dexMeta, err := resolver.info.GetPerpDexMetaWithContext(ctx, dex.Name)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, perpAssets(dexMeta, dex.Name, index)...)
+	}
+	spotMeta, err := resolver.info.GetSpotMetaWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assets = append(assets, spotAssets(spotMeta)...)
+	return newAssetTable(assets, buildSpotTokenMap(spotMeta)), nil
+}
+
+// perpAssets returns the markets of a perp dex, dexIndex being the position of the dex in GetPerpDexs.
+func perpAssets(meta *Meta, dex string, dexIndex int) []ResolvedAsset {
+	assets := make([]ResolvedAsset, 0, len(meta.Universe))
+	for index, asset := range meta.Universe {
+		assetId := index
+		name := asset.Name
+		if dexIndex > 0 {
+			assetId = PERP_DEX_ASSET_OFFSET + dexIndex*PERP_DEX_ASSET_STRIDE + index
+			if !strings.HasPrefix(name, dex+":") {
+				name = dex + ":" + name
+			}
+		}
+		assets = append(assets, ResolvedAsset{
+			Name:         name,
+			AssetId:      assetId,
+			Index:        index,
+			MarketType:   MarketTypePerp,
+			Dex:          dex,
+			SzDecimals:   asset.SzDecimals,
+			PxDecimals:   PERP_MAX_DECIMALS - asset.SzDecimals,
+			MaxLeverage:  asset.MaxLeverage,
+			OnlyIsolated: asset.OnlyIsolated,
+			IsDelisted:   asset.IsDelisted,
+		})
+	}
+	return assets
+}
+
+// spotAssets returns the spot markets, their size decimals being the ones of the base token.
+func spotAssets(spotMeta *SpotMeta) []ResolvedAsset {
+	tokens := make(map[int]SpotTokenInfo, len(spotMeta.Tokens))
+	for _, token := range spotMeta.Tokens {
+		tokens[token.Index] = SpotTokenInfo{Name: token.Name, SzDecimals: token.SzDecimals, WeiDecimals: token.WeiDecimals}
+	}
+	assets := make([]ResolvedAsset, 0, len(spotMeta.Universe))
+	for _, universe := range spotMeta.Universe {
+		if len(universe.Tokens) != 2 {
+			continue
+		}
+		base, quote := tokens[universe.Tokens[0]], tokens[universe.Tokens[1]]
+		assets = append(assets, ResolvedAsset{
+			Name:        universe.Name,
+			AssetId:     assetIdToWire(universe.Index, true),
+			Index:       universe.Index,
+			MarketType:  MarketTypeSpot,
+			SzDecimals:  base.SzDecimals,
+			WeiDecimals: base.WeiDecimals,
+			PxDecimals:  SPOT_MAX_DECIMALS - base.SzDecimals,
+			BaseToken:   base.Name,
+			QuoteToken:  quote.Name,
+		})
+	}
+	return assets
+}
+
+// legacyAsset converts an entry of the BuildMetaMap or BuildSpotMetaMap maps.
+func legacyAsset(name string, info AssetInfo, isSpot bool) ResolvedAsset {
+	if isSpot {
+		market := info.SpotName
+		if market == "" {
+			market = "@" + strconv.Itoa(info.AssetId)
+		}
+		return ResolvedAsset{
+			Name:        market,
+			AssetId:     assetIdToWire(info.AssetId, true),
+			Index:       info.AssetId,
+			MarketType:  MarketTypeSpot,
+			SzDecimals:  info.SzDecimals,
+			WeiDecimals: info.WeiDecimals,
+			PxDecimals:  SPOT_MAX_DECIMALS - info.SzDecimals,
+			BaseToken:   name,
+		}
+	}
+	asset := ResolvedAsset{
+		Name:       name,
+		AssetId:    info.AssetId,
+		Index:      info.AssetId,
+		MarketType: MarketTypePerp,
+		SzDecimals: info.SzDecimals,
+		PxDecimals: PERP_MAX_DECIMALS - info.SzDecimals,
+	}
+	if info.AssetId >= PERP_DEX_ASSET_OFFSET {
+		asset.Dex, _, _ = strings.Cut(name, ":")
+		asset.Index = (info.AssetId - PERP_DEX_ASSET_OFFSET) % PERP_DEX_ASSET_STRIDE
+	}
+	return asset
+}
+
+// assetTable is an immutable index of the markets, replaced as a whole on refresh.
+type assetTable struct {
+	assets       []ResolvedAsset // sorted by asset id
+	ids          map[int]int     // asset id to position in assets
+	perps        map[string]int  // perp name to position in assets
+	spots        map[string]int  // spot market name, "@index" and "BASE/QUOTE" to position in assets
+	tokenMarkets map[string]int  // base token to the position of its market in assets
+	tokens       map[string]SpotTokenInfo
+}
+
+func newAssetTable(assets []ResolvedAsset, tokens map[string]SpotTokenInfo) *assetTable {
+	assets = slices.Clone(assets)
+	slices.SortStableFunc(assets, func(a, b ResolvedAsset) int {
+		return cmp.Compare(a.AssetId, b.AssetId)
+	})
+	if tokens == nil {
+		tokens = map[string]SpotTokenInfo{}
+	}
+	table := &assetTable{
+		assets:       assets,
+		ids:          make(map[int]int, len(assets)),
+		perps:        map[string]int{},
+		spots:        map[string]int{},
+		tokenMarkets: map[string]int{},
+		tokens:       tokens,
+	}
+	for i, asset := range assets {
+		table.ids[asset.AssetId] = i
+		if !asset.IsSpot() {
+			table.perps[asset.Name] = i
+			continue
+		}
+		table.spots[asset.Name] = i
+		table.spots["@"+strconv.Itoa(asset.Index)] = i
+	}
+	// the aliases never shadow a market name
+	for i, asset := range assets {
+		if !asset.IsSpot() || asset.BaseToken == "" {
+			continue
+		}
+		if asset.QuoteToken != "" {
+			if _, ok := table.spots[asset.BaseToken+"/"+asset.QuoteToken]; !ok {
+				table.spots[asset.BaseToken+"/"+asset.QuoteToken] = i
+			}
+		}
+		// a token listed in several markets resolves to its market against USDC, or to its first market
+		if j, ok := table.tokenMarkets[asset.BaseToken]; !ok || (assets[j].QuoteToken != "USDC" && asset.QuoteToken == "USDC") {
+			table.tokenMarkets[asset.BaseToken] = i
+		}
+	}
+	return table
+}
+
+// legacyAssetTable builds the table from the name maps of a snapshot without Assets.
+// Every key of SpotMeta keeps resolving to its market, as with BuildSpotMetaMap.
+func legacyAssetTable(snapshot MetaSnapshot) *assetTable {
+	var assets []ResolvedAsset
+	for name, info := range snapshot.Meta {
+		assets = append(assets, legacyAsset(name, info, false))
+	}
+	tokenNames := slices.Sorted(maps.Keys(snapshot.SpotMeta))
+	seen := map[int]bool{}
+	for _, name := range tokenNames {
+		info := snapshot.SpotMeta[name]
+		if !seen[info.AssetId] {
+			seen[info.AssetId] = true
+			assets = append(assets, legacyAsset(name, info, true))
+		}
+	}
+	table := newAssetTable(assets, maps.Clone(snapshot.SpotTokens))
+	for _, name := range tokenNames {
+		table.tokenMarkets[name] = table.ids[assetIdToWire(snapshot.SpotMeta[name].AssetId, true)]
+	}
+	return table
+}
+
+// legacyMaps returns the name maps of BuildMetaMap and BuildSpotMetaMap, the perp dexes included.
+func (table *assetTable) legacyMaps() (map[string]AssetInfo, map[string]AssetInfo) {
+	meta := make(map[string]AssetInfo, len(table.perps))
+	for name, i := range table.perps {
+		meta[name] = AssetInfo{
+			SzDecimals: table.assets[i].SzDecimals,
+			AssetId:    table.assets[i].AssetId,
+		}
+	}
+	spotMeta := make(map[string]AssetInfo, len(table.tokenMarkets))
+	for token, i := range table.tokenMarkets {
+		asset := table.assets[i]
+		spotMeta[token] = AssetInfo{
+			SzDecimals:  asset.SzDecimals,
+			WeiDecimals: asset.WeiDecimals,
+			AssetId:     asset.Index,
+			SpotName:    asset.Name,
+		}
+	}
+	return meta, spotMeta
+}
+
+func (table *assetTable) perp(symbol string) (ResolvedAsset, bool) {
+	if i, ok := table.perps[symbol]; ok {
+		return table.assets[i], true
+	}
+	return ResolvedAsset{}, false
+}
+
+func (table *assetTable) spot(symbol string) (ResolvedAsset, bool) {
+	if i, ok := table.spots[symbol]; ok {
+		return table.assets[i], true
+	}
+	if i, ok := table.tokenMarkets[symbol]; ok {
+		return table.assets[i], true
+	}
+	return ResolvedAsset{}, false
+}
+
+// Init fetches the market metadata from the /info endpoint and replaces the cached copy.
+// Calling it is optional: the metadata is loaded lazily on first use,
+// but Init allows to fail fast on startup.
+func (api *InfoAPI) Init(ctx context.Context) error {
+	return api.assets.Refresh(ctx)
+}
+
+// LoadMetaSnapshot seeds the metadata cache, e.g. from a snapshot saved by a previous run.
+// No request is made until the cache is refreshed with Init.
+func (api *InfoAPI) LoadMetaSnapshot(snapshot MetaSnapshot) {
+	api.assets.Load(snapshot)
+}
+
+// MetaSnapshot returns a copy of the cached metadata. The maps are nil if nothing is loaded yet.
+func (api *InfoAPI) MetaSnapshot() MetaSnapshot {
+	return api.assets.Snapshot()
+}
+
+// AssetResolver returns the resolver of the market metadata.
+func (api *InfoAPI) AssetResolver() *AssetResolver {
+	return api.assets
+}
+
+// SetAssetResolver shares a resolver, e.g. the one of an ExchangeAPI, so the metadata is loaded only once.
+func (api *InfoAPI) SetAssetResolver(resolver *AssetResolver) {
+	if resolver != nil {
+		api.assets = resolver
+	}
+}
+
+// Init fetches the market metadata used to build orders. See InfoAPI.Init.
+func (api *ExchangeAPI) Init(ctx context.Context) error {
+	return api.infoAPI.Init(ctx)
+}
+
+// LoadMetaSnapshot seeds the market metadata used to build orders. See InfoAPI.LoadMetaSnapshot.
+func (api *ExchangeAPI) LoadMetaSnapshot(snapshot MetaSnapshot) {
+	api.infoAPI.LoadMetaSnapshot(snapshot)
+}
+
+// MetaSnapshot returns a copy of the cached market metadata.
+func (api *ExchangeAPI) MetaSnapshot() MetaSnapshot {
+	return api.infoAPI.MetaSnapshot()
+}
+
+// AssetResolver returns the resolver of the market metadata used to build orders.
+func (api *ExchangeAPI) AssetResolver() *AssetResolver {
+	return api.infoAPI.AssetResolver()
+}
+
+// SetAssetResolver shares a resolver with other APIs, see InfoAPI.SetAssetResolver.
+func (api *ExchangeAPI) SetAssetResolver(resolver *AssetResolver) {
+	api.infoAPI.SetAssetResolver(resolver)
+}
+
+// resolveAsset returns the asset of coin in the perp or spot market, see AssetResolver.
+// Unknown coins fail with ErrUnknownAsset, unless WithLegacyAssetFallback is set.
+func (api *ExchangeAPI) resolveAsset(ctx context.Context, coin string, isSpot bool) (ResolvedAsset, error) {
+	asset, err := api.lookupAsset(ctx, func() (ResolvedAsset, error) {
+		if isSpot {
+			return api.infoAPI.assets.ResolveSpot(ctx, coin)
+		}
+		return api.infoAPI.assets.ResolvePerp(ctx, coin)
+	})
+	if errors.Is(err, ErrUnknownAsset) && api.legacyAssets {
+		return legacyAsset(coin, AssetInfo{}, isSpot), nil
+	}
+	return asset, err
+}
+
+// resolveAnyAsset returns the asset of coin when the market is not known, e.g. for open orders:
+// perps are looked up first, then spot tokens and markets. Use the market name ("@107")
+// for the spot tokens that share their name with a perp.
+func (api *ExchangeAPI) resolveAnyAsset(ctx context.Context, coin string) (ResolvedAsset, error) {
+	asset, err := api.lookupAsset(ctx, func() (ResolvedAsset, error) {
+		return api.infoAPI.assets.Resolve(ctx, coin)
+	})
+	if errors.Is(err, ErrUnknownAsset) && api.legacyAssets {
+		return legacyAsset(coin, AssetInfo{}, false), nil
+	}
+	return asset, err
+}
+
+// lookupAsset refreshes the metadata once if resolve does not know the asset,
+// which may have been listed after the metadata was loaded, and calls resolve again.
+// The refresh is skipped if the metadata was fetched less than ASSET_REFRESH_MIN_INTERVAL ago,
+// so repeated or concurrent lookups of an unknown coin fetch it at most once per interval.
+func (api *ExchangeAPI) lookupAsset(ctx context.Context, resolve func() (ResolvedAsset, error)) (ResolvedAsset, error) {
+	asset, err := resolve()
+	if !errors.Is(err, ErrUnknownAsset) {
+		return asset, err
+	}
+	if refreshErr := api.infoAPI.assets.refresh(ctx, ASSET_REFRESH_MIN_INTERVAL); refreshErr != nil {
+		api.debug("Error refreshing the metadata: %s", refreshErr)
+		return ResolvedAsset{}, errors.Join(err, refreshErr)
+	}
+	return resolve()
+}
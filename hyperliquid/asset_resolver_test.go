@@ -0,0 +1,334 @@
+package hyperliquid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetaCache_LazyConstruction(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	api := NewExchangeAPI(true, WithBaseURL(server.URL))
+	if calls.Load() != 0 {
+		t.Errorf("NewExchangeAPI() made %d requests, want 0", calls.Load())
+	}
+	if err := api.Init(context.Background()); err == nil {
+		t.Errorf("Init() error = nil, want error")
+	}
+
+	api.LoadMetaSnapshot(MetaSnapshot{
+		Meta: map[string]AssetInfo{"ETH": {SzDecimals: 4, AssetId: 1}},
+	})
+	calls.Store(0)
+	orderRequest := OrderRequest{
+		Coin:      "ETH",
+		IsBuy:     true,
+		Sz:        0.1,
+		LimitPx:   2500,
+		OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}},
+	}
+	if _, err := api.BuildOrderEIP712(orderRequest, GroupingNa); err != nil {
+		t.Errorf("BuildOrderEIP712() error = %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("BuildOrderEIP712() made %d requests, want 0", calls.Load())
+	}
+	if precision := api.GetCachedFuturesMarketPrecision(); precision["ETH"] != 4 {
+		t.Errorf("GetCachedFuturesMarketPrecision() = %v, want ETH: 4", precision)
+	}
+}
+
+func TestAssetResolver(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var request InfoRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		switch {
+		case request.Typez == "meta" && request.Dex == "":
+			w.Write([]byte(`{"universe":[{"name":"BTC","szDecimals":5,"maxLeverage":40},{"name":"ETH","szDecimals":4,"maxLeverage":25},` +
+				`{"name":"HYPE","szDecimals":2,"maxLeverage":10}]}`))
+		case request.Typez == "meta" && request.Dex == "xyz":
+			w.Write([]byte(`{"universe":[{"name":"xyz:XYZ100","szDecimals":4,"maxLeverage":20,"onlyIsolated":true}]}`))
+		case request.Typez == "perpDexs":
+			w.Write([]byte(`[null,{"name":"xyz","full_name":"XYZ","deployer":"0x0000000000000000000000000000000000000001","oracle_updater":null}]`))
+		case request.Typez == "spotMeta":
+			w.Write([]byte(`{"universe":[{"tokens":[1,0],"name":"PURR/USDC","index":0,"isCanonical":true},` +
+				`{"tokens":[150,1],"name":"@106","index":106,"isCanonical":false},` +
+				`{"tokens":[150,0],"name":"@107","index":107,"isCanonical":false}],` +
+				`"tokens":[{"name":"USDC","szDecimals":8,"weiDecimals":8,"index":0,"tokenId":"0x6d1e7cde53ba9467b783cb7c530ce054"},` +
+				`{"name":"PURR","szDecimals":0,"weiDecimals":5,"index":1,"tokenId":"0xc1fb593aeffbeb02f85e0308e9956a90"},` +
+				`{"name":"HYPE","szDecimals":2,"weiDecimals":8,"index":150,"tokenId":"0x0d01dc56dcaaca66ad901c959b4011ec"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	api := NewExchangeAPI(true, WithBaseURL(server.URL))
+	resolver := api.AssetResolver()
+	if err := resolver.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	tests := []struct {
+		symbol string
+		isSpot bool
+		want   ResolvedAsset
+	}{
+		{"ETH", false, ResolvedAsset{Name: "ETH", AssetId: 1, Index: 1, MarketType: MarketTypePerp, SzDecimals: 4, PxDecimals: 2, MaxLeverage: 25}},
+		{"xyz:XYZ100", false, ResolvedAsset{Name: "xyz:XYZ100", AssetId: 110000, Index: 0, MarketType: MarketTypePerp, Dex: "xyz", SzDecimals: 4, PxDecimals: 2, MaxLeverage: 20, OnlyIsolated: true}},
+		{"PURR/USDC", true, ResolvedAsset{Name: "PURR/USDC", AssetId: 10000, Index: 0, MarketType: MarketTypeSpot, SzDecimals: 0, WeiDecimals: 5, PxDecimals: 8, BaseToken: "PURR", QuoteToken: "USDC"}},
+		{"@106", true, ResolvedAsset{Name: "@106", AssetId: 10106, Index: 106, MarketType: MarketTypeSpot, SzDecimals: 2, WeiDecimals: 8, PxDecimals: 6, BaseToken: "HYPE", QuoteToken: "PURR"}},
+		{"HYPE/PURR", true, ResolvedAsset{Name: "@106", AssetId: 10106, Index: 106, MarketType: MarketTypeSpot, SzDecimals: 2, WeiDecimals: 8, PxDecimals: 6, BaseToken: "HYPE", QuoteToken: "PURR"}},
+		// a token listed in several markets resolves to its market against USDC
+		{"HYPE", true, ResolvedAsset{Name: "@107", AssetId: 10107, Index: 107, MarketType: MarketTypeSpot, SzDecimals: 2, WeiDecimals: 8, PxDecimals: 6, BaseToken: "HYPE", QuoteToken: "USDC"}},
+		{"HYPE/USDC", true, ResolvedAsset{Name: "@107", AssetId: 10107, Index: 107, MarketType: MarketTypeSpot, SzDecimals: 2, WeiDecimals: 8, PxDecimals: 6, BaseToken: "HYPE", QuoteToken: "USDC"}},
+		{"@0", true, ResolvedAsset{Name: "PURR/USDC", AssetId: 10000, Index: 0, MarketType: MarketTypeSpot, SzDecimals: 0, WeiDecimals: 5, PxDecimals: 8, BaseToken: "PURR", QuoteToken: "USDC"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			resolve := resolver.ResolvePerp
+			if tt.isSpot {
+				resolve = resolver.ResolveSpot
+			}
+			got, err := resolve(context.Background(), tt.symbol)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+			byId, err := resolver.ResolveAssetId(context.Background(), tt.want.AssetId)
+			if err != nil || byId != tt.want {
+				t.Errorf("ResolveAssetId() = %+v, %v, want %+v", byId, err, tt.want)
+			}
+		})
+	}
+
+	// perps are looked up first, ResolveSpot finds the token
+	if asset, err := resolver.Resolve(context.Background(), "HYPE"); err != nil || asset.AssetId != 2 {
+		t.Errorf("Resolve(HYPE) = %+v, %v, want the perp", asset, err)
+	}
+	if asset, err := resolver.ResolveSpot(context.Background(), "HYPE"); err != nil || asset.AssetId != 10107 {
+		t.Errorf("ResolveSpot(HYPE) = %+v, %v, want @107", asset, err)
+	}
+	if _, err := resolver.Resolve(context.Background(), "NEW"); !errors.Is(err, ErrUnknownAsset) {
+		t.Errorf("Resolve(NEW) error = %v, want ErrUnknownAsset", err)
+	}
+	if token, err := resolver.SpotToken(context.Background(), "HYPE:0x0d01dc56dcaaca66ad901c959b4011ec"); err != nil || token.Index != 150 {
+		t.Errorf("SpotToken() = %+v, %v", token, err)
+	}
+
+	action, err := api.buildOrderAction(context.Background(), []OrderRequest{{
+		Coin:      "xyz:XYZ100",
+		IsBuy:     true,
+		Sz:        1.23456,
+		LimitPx:   101.2345,
+		OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}},
+	}}, GroupingNa, false)
+	if err != nil {
+		t.Fatalf("buildOrderAction() error = %v", err)
+	}
+	if order := action.Orders[0]; order.Asset != 110000 || order.SizePx != "1.2346" || order.LimitPx != "101.23" {
+		t.Errorf("order = %+v", order)
+	}
+
+	// a snapshot restores the perp dexes and the spot markets
+	restored := NewInfoAPI(true, WithBaseURL("http://127.0.0.1:0"))
+	restored.LoadMetaSnapshot(api.MetaSnapshot())
+	for _, symbol := range []string{"xyz:XYZ100", "HYPE/PURR"} {
+		want, _ := resolver.Resolve(context.Background(), symbol)
+		if got, err := restored.AssetResolver().Resolve(context.Background(), symbol); err != nil || got != want {
+			t.Errorf("restored Resolve(%s) = %+v, %v, want %+v", symbol, got, err, want)
+		}
+	}
+	if got, err := restored.AssetResolver().ResolveSpot(context.Background(), "HYPE"); err != nil || got.Name != "@107" {
+		t.Errorf("restored ResolveSpot(HYPE) = %+v, %v, want @107", got, err)
+	}
+
+	ws := NewWebSocketAPI(true)
+	ws.SetAssetResolver(resolver)
+	for symbol, want := range map[string]string{"HYPE/USDC": "@107", "BTC": "BTC", "NEW": "NEW"} {
+		if got := ws.coinName(symbol); got != want {
+			t.Errorf("coinName(%s) = %s, want %s", symbol, got, want)
+		}
+	}
+	// the subscriptions do not fetch the metadata
+	ws.SetAssetResolver(NewInfoAPI(true, WithBaseURL(server.URL)).AssetResolver())
+	served := requests.Load()
+	if got := ws.coinName("HYPE/USDC"); got != "HYPE/USDC" || requests.Load() != served {
+		t.Errorf("coinName(HYPE/USDC) before loading = %s after %d requests, want HYPE/USDC without request", got, requests.Load()-served)
+	}
+}
+
+func TestAssetResolver_Refresh(t *testing.T) {
+	var version atomic.Int32
+	version.Store(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request InfoRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		switch request.Typez {
+		case "meta":
+			if version.Load() == 1 {
+				w.Write([]byte(`{"universe":[{"name":"BTC","szDecimals":5,"maxLeverage":40},{"name":"ETH","szDecimals":4,"maxLeverage":25},` +
+					`{"name":"OLD","szDecimals":0,"maxLeverage":3}]}`))
+				return
+			}
+			w.Write([]byte(`{"universe":[{"name":"BTC","szDecimals":5,"maxLeverage":50},{"name":"ETH","szDecimals":4,"maxLeverage":25},` +
+				`{"name":"OLD","szDecimals":0,"maxLeverage":3,"isDelisted":true},{"name":"NEW","szDecimals":1,"maxLeverage":5}]}`))
+		case "perpDexs":
+			w.Write([]byte(`[null]`))
+		case "spotMeta":
+			if version.Load() == 1 {
+				w.Write([]byte(`{"universe":[{"tokens":[1,0],"name":"PURR/USDC","index":0}],` +
+					`"tokens":[{"name":"USDC","szDecimals":8,"weiDecimals":8,"index":0},{"name":"PURR","szDecimals":0,"weiDecimals":5,"index":1}]}`))
+				return
+			}
+			w.Write([]byte(`{"universe":[{"tokens":[1,0],"name":"PURR/USDC","index":0},{"tokens":[2,0],"name":"@1","index":1}],` +
+				`"tokens":[{"name":"USDC","szDecimals":8,"weiDecimals":8,"index":0},{"name":"PURR","szDecimals":0,"weiDecimals":5,"index":1},` +
+				`{"name":"NEWT","szDecimals":1,"weiDecimals":6,"index":2}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	newOrder := []OrderRequest{{
+		Coin:      "NEW",
+		IsBuy:     true,
+		Sz:        1,
+		LimitPx:   10,
+		OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}},
+	}}
+	api := NewExchangeAPI(true, WithBaseURL(server.URL))
+	if err := api.Init(context.Background()); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if _, err := api.buildOrderAction(context.Background(), newOrder, GroupingNa, false); !errors.Is(err, ErrUnknownAsset) {
+		t.Errorf("buildOrderAction() error = %v, want ErrUnknownAsset", err)
+	}
+	legacy := NewExchangeAPI(true, WithBaseURL(server.URL), WithLegacyAssetFallback())
+	legacy.SetAssetResolver(api.AssetResolver())
+	if action, err := legacy.buildOrderAction(context.Background(), newOrder, GroupingNa, false); err != nil || action.Orders[0].Asset != 0 {
+		t.Errorf("buildOrderAction() with the legacy fallback = %+v, %v, want asset 0", action, err)
+	}
+	snapshot := api.MetaSnapshot()
+
+	var mu sync.Mutex
+	var listed, delisted []string
+	var changed []string
+	done := make(chan struct{})
+	api.AssetResolver().AddListener(AssetListener{
+		OnListed: func(asset ResolvedAsset) {
+			mu.Lock()
+			defer mu.Unlock()
+			listed = append(listed, asset.Name)
+			if _, err := api.AssetResolver().Resolve(context.Background(), asset.Name); err != nil {
+				t.Errorf("Resolve(%s) in OnListed error = %v", asset.Name, err)
+			}
+		},
+		OnDelisted: func(asset ResolvedAsset) {
+			mu.Lock()
+			defer mu.Unlock()
+			delisted = append(delisted, asset.Name)
+		},
+		OnChanged: func(previous ResolvedAsset, asset ResolvedAsset) {
+			mu.Lock()
+			defer mu.Unlock()
+			changed = append(changed, fmt.Sprintf("%s %d->%d", asset.Name, previous.MaxLeverage, asset.MaxLeverage))
+			close(done)
+		},
+	})
+
+	version.Store(2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go api.AssetResolver().RunRefresh(ctx, 10*time.Millisecond, func(err error) {
+		t.Errorf("RunRefresh() error = %v", err)
+	})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunRefresh() did not report the changes")
+	}
+	cancel()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(listed, []string{"NEW", "@1"}) {
+		t.Errorf("listed = %v, want [NEW @1]", listed)
+	}
+	if !slices.Equal(delisted, []string{"OLD"}) {
+		t.Errorf("delisted = %v, want [OLD]", delisted)
+	}
+	if !slices.Equal(changed, []string{"BTC 40->50"}) {
+		t.Errorf("changed = %v, want [BTC 40->50]", changed)
+	}
+	if action, err := api.buildOrderAction(context.Background(), newOrder, GroupingNa, false); err != nil || action.Orders[0].Asset != 3 {
+		t.Errorf("buildOrderAction() after refresh = %+v, %v, want asset 3", action, err)
+	}
+
+	// an unknown coin refreshes the metadata before failing
+	stale := NewExchangeAPI(true, WithBaseURL(server.URL))
+	stale.LoadMetaSnapshot(snapshot)
+	if action, err := stale.buildOrderAction(context.Background(), newOrder, GroupingNa, false); err != nil || action.Orders[0].Asset != 3 {
+		t.Errorf("buildOrderAction() of a new listing = %+v, %v, want asset 3", action, err)
+	}
+}
+
+func TestExchangeAPI_UnknownAssetRefresh(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request InfoRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		switch request.Typez {
+		case "meta":
+			fetches.Add(1)
+			time.Sleep(20 * time.Millisecond) // keeps the first refresh in flight while the other lookups arrive
+			w.Write([]byte(`{"universe":[{"name":"BTC","szDecimals":5,"maxLeverage":40}]}`))
+		case "perpDexs":
+			w.Write([]byte(`[null]`))
+		case "spotMeta":
+			w.Write([]byte(`{"universe":[],"tokens":[{"name":"USDC","szDecimals":8,"weiDecimals":8,"index":0}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	api := NewExchangeAPI(true, WithBaseURL(server.URL))
+	api.LoadMetaSnapshot(MetaSnapshot{Meta: map[string]AssetInfo{"BTC": {SzDecimals: 5, AssetId: 0}}})
+	orders := []OrderRequest{{Coin: "MISSING", IsBuy: true, Sz: 1, LimitPx: 10, OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}}}}
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := api.buildOrderAction(context.Background(), orders, GroupingNa, false); !errors.Is(err, ErrUnknownAsset) {
+				t.Errorf("buildOrderAction() error = %v, want ErrUnknownAsset", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := api.buildOrderAction(context.Background(), orders, GroupingNa, false); !errors.Is(err, ErrUnknownAsset) {
+		t.Errorf("buildOrderAction() error = %v, want ErrUnknownAsset", err)
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("lookups of an unknown coin fetched the metadata %d times, want 1", got)
+	}
+}
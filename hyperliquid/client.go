@@ -2,6 +2,7 @@ package hyperliquid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -18,9 +20,13 @@ import (
 // IsMainnet method returns true if the client is connected to the mainnet.
 // debug method enables debug mode.
 // SetPrivateKey method sets the private key for the client.
+// SetKeyManager method sets the private key manager for the client.
+// SetSigner method sets an external signer for the client.
 type IClient interface {
 	IAPIService
 	SetPrivateKey(privateKey string) error
+	SetKeyManager(keyManager *PKeyManager)
+	SetSigner(signer Signer)
 	SetAccountAddress(address string)
 	AccountAddress() string
 	SetDebugActive()
@@ -33,21 +39,87 @@ type IClient interface {
 // the network type, the private key, and the logger.
 // The debug method prints the debug messages.
 type Client struct {
-	baseUrl        string       // Base URL of the HyperLiquid API
-	privateKey     string       // Private key for the client
-	defualtAddress string       // Default address for the client
-	isMainnet      bool         // Network type
-	Debug          bool         // Debug mode
-	httpClient     *http.Client // HTTP client
-	keyManager     *PKeyManager // Private key manager
-	Logger         *log.Logger  // Logger for debug messages
+	baseUrl        string            // Base URL of the HyperLiquid API
+	wsUrl          string            // URL of the HyperLiquid WebSocket API
+	defualtAddress string            // Default address for the client
+	isMainnet      bool              // Network type
+	Debug          bool              // Debug mode
+	httpClient     *http.Client      // HTTP client
+	transport      http.RoundTripper // Transport of the HTTP client, applied after the options
+	wsDialer       *websocket.Dialer // WebSocket dialer
+	retryPolicy    *RetryPolicy      // Retry policy, nil disables retries
+	rateLimiter    *RateLimiter      // Client-side rate limiter, nil disables it
+	keyManager     *PKeyManager      // Private key manager
+	signer         Signer            // Signer of exchange actions
+	nonceManager   NonceManager      // Nonces of exchange actions
+	legacyAssets   bool              // Unknown coins resolve to the first asset instead of failing
+	Logger         *log.Logger       // Logger for debug messages
+}
+
+// ClientOption configures optional parameters of a Client.
+// Options are applied by NewClient and by every constructor that builds a Client
+// (NewInfoAPI, NewExchangeAPI, NewWebSocketAPI and NewHyperliquid).
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used for REST requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) {
+		if httpClient != nil {
+			client.httpClient = httpClient
+		}
+	}
+}
+
+// WithTransport sets the RoundTripper used for REST requests.
+// It applies to the HTTP client of WithHTTPClient whatever the order of the options.
+// The HTTP client is copied, so http.DefaultClient is never modified.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(client *Client) {
+		client.transport = transport
+	}
+}
+
+// WithBaseURL overrides the REST API URL, e.g. to use a proxy, a private node or an httptest.Server.
+func WithBaseURL(url string) ClientOption {
+	return func(client *Client) {
+		client.baseUrl = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithWSURL overrides the WebSocket API URL.
+func WithWSURL(url string) ClientOption {
+	return func(client *Client) {
+		client.wsUrl = url
+	}
+}
+
+// WithDialer sets the dialer used to open WebSocket connections.
+func WithDialer(dialer *websocket.Dialer) ClientOption {
+	return func(client *Client) {
+		if dialer != nil {
+			client.wsDialer = dialer
+		}
+	}
 }
 
 // Returns the private key manager connected to the API.
+// It is nil if the API signs with an external Signer.
 func (client *Client) KeyManager() *PKeyManager {
 	return client.keyManager
 }
 
+// Returns the signer of exchange actions.
+func (client *Client) Signer() Signer {
+	return client.signer
+}
+
+// SetSigner sets the signer of exchange actions, e.g. a RemoteSigner.
+// It replaces the private key set with SetPrivateKey.
+func (client *Client) SetSigner(signer Signer) {
+	client.signer = signer
+	client.keyManager = nil
+}
+
 // getAPIURL returns the API URL based on the network type.
 func getURL(isMainnet bool) string {
 	if isMainnet {
@@ -57,8 +129,18 @@ func getURL(isMainnet bool) string {
 	}
 }
 
+// getWSURL returns the WebSocket URL based on the network type.
+func getWSURL(isMainnet bool) string {
+	if isMainnet {
+		return MAINNET_WS_URL
+	}
+	return TESTNET_WS_URL
+}
+
 // NewClient returns a new instance of the Client struct.
-func NewClient(isMainnet bool) *Client {
+// By default it talks to the public API of the selected network with http.DefaultClient,
+// use ClientOption values to override it.
+func NewClient(isMainnet bool, opts ...ClientOption) *Client {
 	logger := log.New()
 	logger.SetFormatter(&log.TextFormatter{
 		FullTimestamp: true,
@@ -66,16 +148,27 @@ func NewClient(isMainnet bool) *Client {
 	})
 	logger.SetOutput(os.Stdout)
 	logger.SetLevel(log.DebugLevel)
-	return &Client{
+	client := &Client{
 		baseUrl:        getURL(isMainnet),
+		wsUrl:          getWSURL(isMainnet),
 		httpClient:     http.DefaultClient,
+		wsDialer:       websocket.DefaultDialer,
 		Debug:          false,
 		isMainnet:      isMainnet,
-		privateKey:     "",
 		defualtAddress: "",
 		Logger:         logger,
 		keyManager:     nil,
+		nonceManager:   defaultNonceManager,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	if client.transport != nil {
+		httpClient := *client.httpClient
+		httpClient.Transport = client.transport
+		client.httpClient = &httpClient
 	}
+	return client
 }
 
 // debug prints the debug messages.
@@ -86,12 +179,23 @@ func (client *Client) debug(format string, v ...any) {
 }
 
 // SetPrivateKey sets the private key for the client.
+// Prefer SetKeyManager with a key loaded by NewPKeyManagerFromKeystore,
+// NewPKeyManagerFromEnv or NewPKeyManagerFromFD to keep the key out of config files.
 func (client *Client) SetPrivateKey(privateKey string) error {
-	privateKey = strings.TrimPrefix(privateKey, "0x")
-	client.privateKey = privateKey
-	var err error
-	client.keyManager, err = NewPKeyManager(privateKey)
-	return err
+	keyManager, err := NewPKeyManager(privateKey)
+	if err != nil {
+		client.keyManager = nil
+		client.signer = nil
+		return err
+	}
+	client.SetKeyManager(keyManager)
+	return nil
+}
+
+// SetKeyManager sets the private key manager that signs exchange actions.
+func (client *Client) SetKeyManager(keyManager *PKeyManager) {
+	client.keyManager = keyManager
+	client.signer = NewPrivateKeySigner(keyManager)
 }
 
 // Some methods need public address to gather info (from infoAPI).
@@ -118,34 +222,75 @@ func (client *Client) SetDebugActive() {
 
 // Request sends a POST request to the HyperLiquid API.
 func (client *Client) Request(endpoint string, payload any) ([]byte, error) {
+	return client.RequestWithContext(context.Background(), endpoint, payload)
+}
+
+// RequestWithContext sends a POST request to the HyperLiquid API.
+// The context is attached to the underlying HTTP request, so cancelling it
+// aborts the round trip and any pending retry.
+func (client *Client) RequestWithContext(ctx context.Context, endpoint string, payload any) ([]byte, error) {
 	endpoint = strings.TrimPrefix(endpoint, "/") // Remove leading slash if present
 	url := fmt.Sprintf("%s/%s", client.baseUrl, endpoint)
 	client.debug("Request to %s", url)
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		client.debug("Error json.Marshal: %s", err)
-		return nil, err
-	}
-	client.debug("Request payload: %s", string(jsonPayload))
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		client.debug("Error json.Marshal: %s", err)
 		return nil, err
 	}
-	request, err := http.NewRequest("POST", url, bytes.NewBuffer(payloadBytes))
+	client.debug("Request payload: %s", string(payloadBytes))
+
+	maxAttempts := 1
+	if client.retryPolicy != nil && isRetrySafe(endpoint, payload) {
+		maxAttempts = max(client.retryPolicy.MaxAttempts, 1)
+	}
+	lost := false // an attempt may have been processed without its response reaching us
+	for attempt := 1; ; attempt++ {
+		if client.rateLimiter != nil {
+			if err := client.rateLimiter.acquire(ctx, endpoint, payloadBytes, attempt == 1); err != nil {
+				client.debug("Error rate limiter: %s", err)
+				return nil, err
+			}
+		}
+		data, statusCode, err := client.send(ctx, url, payloadBytes)
+		if err == nil {
+			if lost && endpoint == "exchange" {
+				if err := possiblyExecutedError(data); err != nil {
+					return nil, &RetryError{Attempts: attempt, Err: err}
+				}
+			}
+			return data, nil
+		}
+		if statusCode == 0 || statusCode >= http.StatusInternalServerError {
+			lost = true
+		}
+		retryable := statusCode == 0 || client.retryPolicy.isRetryableStatus(statusCode)
+		if attempt >= maxAttempts || !retryable || ctx.Err() != nil {
+			if attempt > 1 {
+				return nil, &RetryError{Attempts: attempt, Err: err}
+			}
+			return nil, err
+		}
+		backoff := client.retryPolicy.backoff(attempt)
+		client.debug("Retrying request to %s in %s (attempt %d/%d): %s", url, backoff, attempt+1, maxAttempts, err)
+		if err := sleepContext(ctx, backoff); err != nil {
+			return nil, &RetryError{Attempts: attempt, Err: err}
+		}
+	}
+}
+
+// send makes a single POST request with an already encoded payload.
+// The returned status code is 0 if no response was received.
+func (client *Client) send(ctx context.Context, url string, payload []byte) (data []byte, statusCode int, err error) {
+	request, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
 	if err != nil {
 		client.debug("Error http.NewRequest: %s", err)
-		return nil, err
+		return nil, 0, err
 	}
 	request.Header.Set("Content-Type", "application/json")
 	response, err := client.httpClient.Do(request)
 	if err != nil {
 		client.debug("Error client.httpClient.Do: %s", err)
-		return nil, err
-	}
-	data, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer func() {
 		cerr := response.Body.Close()
@@ -155,12 +300,16 @@ func (client *Client) Request(endpoint string, payload any) ([]byte, error) {
 			err = cerr
 		}
 	}()
+	data, err = io.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, err
+	}
 	client.debug("response: %#v", response)
 	client.debug("response body: %s", string(data))
 	client.debug("response status code: %d", response.StatusCode)
 	if response.StatusCode >= http.StatusBadRequest {
 		// If the status code is 400 or greater, return an error
-		return nil, APIError{Message: fmt.Sprintf("HTTP %d: %s", response.StatusCode, data)}
+		return nil, response.StatusCode, &HTTPError{StatusCode: response.StatusCode, Body: data}
 	}
-	return data, nil
+	return data, response.StatusCode, nil
 }
@@ -0,0 +1,143 @@
+package hyperliquid
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestClient_Options(t *testing.T) {
+	var gotURL string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		gotURL = r.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"BTC":"100000.0"}`)),
+			Header:     make(http.Header),
+		}, nil
+	})
+	api := NewInfoAPI(true, WithBaseURL("http://localhost:3001/"), WithWSURL("ws://localhost:3001/ws"), WithTransport(transport))
+	if api.wsUrl != "ws://localhost:3001/ws" {
+		t.Errorf("wsUrl = %v, want %v", api.wsUrl, "ws://localhost:3001/ws")
+	}
+	if http.DefaultClient.Transport != nil {
+		t.Errorf("WithTransport() modified http.DefaultClient")
+	}
+	mids, err := api.GetAllMids()
+	if err != nil {
+		t.Fatalf("GetAllMids() error = %v", err)
+	}
+	if gotURL != "http://localhost:3001/info" {
+		t.Errorf("request URL = %v, want %v", gotURL, "http://localhost:3001/info")
+	}
+	if (*mids)["BTC"] != "100000.0" {
+		t.Errorf("GetAllMids() = %v, want BTC mid", mids)
+	}
+
+	// the transport applies to the HTTP client set after it
+	httpClient := &http.Client{}
+	api = NewInfoAPI(true, WithTransport(transport), WithHTTPClient(httpClient))
+	if api.httpClient.Transport == nil || httpClient.Transport != nil {
+		t.Errorf("WithTransport() before WithHTTPClient() = %v, given client %v", api.httpClient.Transport, httpClient.Transport)
+	}
+}
+
+func TestClient_RequestWithContextCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(false, WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.RequestWithContext(ctx, "/info", InfoRequest{Typez: "allMids"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RequestWithContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	client := NewClient(false, WithBaseURL(server.URL), WithRetryPolicy(policy))
+	if _, err := client.Request("/info", InfoRequest{Typez: "allMids"}); err != nil {
+		t.Errorf("Request(/info) error = %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Request(/info) attempts = %d, want %d", calls.Load(), 3)
+	}
+
+	// Unsigned payloads to /exchange are never retried
+	calls.Store(0)
+	if _, err := client.Request("/exchange", map[string]any{"type": "order"}); err == nil {
+		t.Errorf("Request(/exchange) error = nil, want error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Request(/exchange) attempts = %d, want %d", calls.Load(), 1)
+	}
+
+	// Signed requests are replayed with the same nonce
+	calls.Store(-10)
+	_, err := client.Request("/exchange", ExchangeRequest{Nonce: 1})
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != policy.MaxAttempts {
+		t.Errorf("Request(/exchange) error = %v, want RetryError after %d attempts", err, policy.MaxAttempts)
+	}
+}
+
+func TestClient_RetryPossiblyExecuted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// the order is applied but the response is lost
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"status":"err","response":"Invalid nonce: duplicate nonce 1"}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	client := NewClient(false, WithBaseURL(server.URL), WithRetryPolicy(policy))
+	_, err := client.Request("/exchange", ExchangeRequest{Nonce: 1})
+	var retryErr *RetryError
+	var exchangeErr *ExchangeError
+	if !errors.As(err, &retryErr) || !errors.Is(err, ErrPossiblyExecuted) || !errors.As(err, &exchangeErr) {
+		t.Errorf("Request(/exchange) error = %v, want RetryError matching ErrPossiblyExecuted", err)
+	}
+
+	// a nonce rejection on the first attempt is returned as is
+	calls.Store(1)
+	if data, err := client.Request("/exchange", ExchangeRequest{Nonce: 1}); err != nil || !strings.Contains(string(data), "nonce") {
+		t.Errorf("Request(/exchange) = %s, %v, want the rejection", data, err)
+	}
+}
@@ -0,0 +1,98 @@
+package hyperliquid
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ErrInvalidCloid is returned when a client order id is not 16 bytes encoded as 0x-prefixed hex.
+var ErrInvalidCloid = errors.New("cloid must be 16 bytes encoded as 0x-prefixed hex")
+
+// Cloid is a client order id: 16 bytes encoded as 0x-prefixed hex,
+// e.g. "0x1234567890abcdef1234567890abcdef" as returned by GetRandomCloid.
+type Cloid string
+
+// ParseCloid validates a client order id and returns it lowercased.
+func ParseCloid(s string) (Cloid, error) {
+	cloid := Cloid(strings.ToLower(s))
+	if err := cloid.Validate(); err != nil {
+		return "", err
+	}
+	return cloid, nil
+}
+
+// NewCloid returns a random client order id.
+func NewCloid() Cloid {
+	return Cloid(GetRandomCloid())
+}
+
+// Validate checks that the client order id is 16 bytes encoded as 0x-prefixed hex.
+func (cloid Cloid) Validate() error {
+	s := string(cloid)
+	if len(s) != 34 || !strings.HasPrefix(s, "0x") {
+		return fmt.Errorf("%w: %q", ErrInvalidCloid, s)
+	}
+	if _, err := hex.DecodeString(s[2:]); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidCloid, s)
+	}
+	return nil
+}
+
+func (cloid Cloid) String() string {
+	return string(cloid)
+}
+
+// target returns the order to modify: its cloid if OrderId is not set, otherwise its oid.
+func (wire ModifyOrderWire) target() any {
+	if wire.OrderId == 0 && wire.Cloid != "" {
+		return string(wire.Cloid)
+	}
+	return wire.OrderId
+}
+
+// EncodeMsgpack encodes the order to modify as {oid, order} where oid is either the oid or the cloid.
+func (wire ModifyOrderWire) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeMapLen(2); err != nil {
+		return err
+	}
+	if err := enc.EncodeString("oid"); err != nil {
+		return err
+	}
+	if err := enc.Encode(wire.target()); err != nil {
+		return err
+	}
+	if err := enc.EncodeString("order"); err != nil {
+		return err
+	}
+	return enc.Encode(wire.Order)
+}
+
+func (wire ModifyOrderWire) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Oid   any       `json:"oid"`
+		Order OrderWire `json:"order"`
+	}{wire.target(), wire.Order})
+}
+
+// UnmarshalJSON implements custom unmarshaling for ModifyOrderWire: oid is either a number or a cloid.
+func (wire *ModifyOrderWire) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Oid   json.RawMessage `json:"oid"`
+		Order OrderWire       `json:"order"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*wire = ModifyOrderWire{Order: raw.Order}
+	var cloid string
+	if err := json.Unmarshal(raw.Oid, &cloid); err == nil {
+		wire.Cloid = Cloid(cloid)
+		return nil
+	}
+	return json.Unmarshal(raw.Oid, &wire.OrderId)
+}
@@ -0,0 +1,125 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestParseCloid(t *testing.T) {
+	tests := []struct {
+		cloid   string
+		want    Cloid
+		wantErr bool
+	}{
+		{cloid: "0x1234567890abcdef1234567890abcdef", want: "0x1234567890abcdef1234567890abcdef"},
+		{cloid: "0x1234567890ABCDEF1234567890ABCDEF", want: "0x1234567890abcdef1234567890abcdef"},
+		{cloid: "1234567890abcdef1234567890abcdef", wantErr: true},
+		{cloid: "0x1234567890abcdef", wantErr: true},
+		{cloid: "0x1234567890abcdef1234567890abcdeg", wantErr: true},
+		{cloid: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCloid(tt.cloid)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseCloid(%q) = %q, %v, want %q", tt.cloid, got, err, tt.want)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidCloid) {
+			t.Errorf("ParseCloid(%q) error = %v, want %v", tt.cloid, err, ErrInvalidCloid)
+		}
+	}
+	if err := NewCloid().Validate(); err != nil {
+		t.Errorf("NewCloid().Validate() = %v", err)
+	}
+}
+
+func TestModifyOrderWire_Encoding(t *testing.T) {
+	order := OrderWire{Asset: 1, IsBuy: true, LimitPx: "2500", SizePx: "0.1", OrderType: OrderTypeWire{Limit: &LimitOrderType{Tif: TifGtc}}}
+
+	// by oid the encoding is unchanged
+	type plainModifyOrderWire struct {
+		OrderId int       `msgpack:"oid"`
+		Order   OrderWire `msgpack:"order"`
+	}
+	want, _ := msgpack.Marshal(plainModifyOrderWire{OrderId: 123456789, Order: order})
+	got, err := msgpack.Marshal(ModifyOrderWire{OrderId: 123456789, Order: order})
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("msgpack.Marshal(by oid) = %x, %v, want %x", got, err, want)
+	}
+
+	cloid := Cloid("0x1234567890abcdef1234567890abcdef")
+	type cloidModifyOrderWire struct {
+		Oid   string    `msgpack:"oid"`
+		Order OrderWire `msgpack:"order"`
+	}
+	want, _ = msgpack.Marshal(cloidModifyOrderWire{Oid: string(cloid), Order: order})
+	got, err = msgpack.Marshal(ModifyOrderWire{Cloid: cloid, Order: order})
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("msgpack.Marshal(by cloid) = %x, %v, want %x", got, err, want)
+	}
+
+	for _, wire := range []ModifyOrderWire{{OrderId: 123456789, Order: order}, {Cloid: cloid, Order: order}} {
+		data, _ := json.Marshal(wire)
+		var decoded ModifyOrderWire
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded.OrderId != wire.OrderId || decoded.Cloid != wire.Cloid {
+			t.Errorf("json round trip of %s = %+v, %v", data, decoded, err)
+		}
+	}
+}
+
+func TestExchangeAPI_ByCloid(t *testing.T) {
+	cloid := Cloid("0x1234567890abcdef1234567890abcdef")
+	exchange := &testExchange{Response: `{"status":"ok","response":{"type":"cancel","data":{"statuses":["success"]}}}`}
+	exchangeAPI, manager := newTestExchangeAPI(t, exchange, &MetaSnapshot{
+		Meta:     map[string]AssetInfo{"ETH": {SzDecimals: 4, AssetId: 1}},
+		SpotMeta: map[string]AssetInfo{"PURR": {SzDecimals: 0, AssetId: 0}},
+	})
+
+	if _, err := exchangeAPI.ModifyOrder(ModifyOrderRequest{
+		Coin:      "PURR",
+		IsBuy:     true,
+		Sz:        100,
+		LimitPx:   0.2,
+		OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}},
+		Cloid:     cloid,
+	}, true); err != nil {
+		t.Fatalf("ModifyOrder() error = %v", err)
+	}
+	modify := exchange.action()["modifies"].([]any)[0].(map[string]any)
+	if modify["oid"] != string(cloid) || modify["order"].(map[string]any)["a"] != float64(10000) || modify["order"].(map[string]any)["c"] != string(cloid) {
+		t.Errorf("batchModify by cloid = %v", modify)
+	}
+	if signer, err := RecoverSigner(exchange.Request, true); err != nil || signer != manager.PublicAddress() {
+		t.Errorf("RecoverSigner(batchModify) = %v, %v, want %v", signer, err, manager.PublicAddress())
+	}
+
+	cancels := []CancelCloidRequest{{Coin: "PURR", Cloid: cloid}}
+	response, err := exchangeAPI.BulkCancelOrdersByCloid(cancels, true)
+	if err != nil {
+		t.Fatalf("BulkCancelOrdersByCloid() error = %v", err)
+	}
+	cancel := exchange.action()["cancels"].([]any)[0].(map[string]any)
+	if cancel["asset"] != float64(10000) || cancel["cloid"] != string(cloid) {
+		t.Errorf("cancelByCloid = %v", cancel)
+	}
+	if results := PairCancelByCloidResults(cancels, response); len(results) != 1 || results[0].Cloid != string(cloid) || results[0].Err != nil {
+		t.Errorf("PairCancelByCloidResults() = %+v", results)
+	}
+
+	exchange.Request = ExchangeRequest{}
+	if _, err := exchangeAPI.CancelOrderByCloid("ETH", "0x1234"); !errors.Is(err, ErrInvalidCloid) {
+		t.Errorf("CancelOrderByCloid() with an invalid cloid error = %v, want %v", err, ErrInvalidCloid)
+	}
+	if _, err := exchangeAPI.Order(OrderRequest{Coin: "ETH", Sz: 1, LimitPx: 2500, Cloid: "order-1"}, GroupingNa); !errors.Is(err, ErrInvalidCloid) {
+		t.Errorf("Order() with an invalid cloid error = %v, want %v", err, ErrInvalidCloid)
+	}
+	if _, err := exchangeAPI.ModifyOrder(ModifyOrderRequest{Coin: "ETH", Sz: 1, LimitPx: 2500, Cloid: "order-1"}, false); !errors.Is(err, ErrInvalidCloid) {
+		t.Errorf("ModifyOrder() with an invalid cloid error = %v, want %v", err, ErrInvalidCloid)
+	}
+	if exchange.Request.Action != nil {
+		t.Errorf("invalid cloids were sent: %v", exchange.Request.Action)
+	}
+}
@@ -1,5 +1,7 @@
 package hyperliquid
 
+import "time"
+
 const GLOBAL_DEBUG = false // Default debug that is used in all tests
 
 // API constants
@@ -16,8 +18,31 @@ const SPOT_MAX_DECIMALS = 8    // Default decimals for spot
 const PERP_MAX_DECIMALS = 6    // Default decimals for perp
 var USDC_SZ_DECIMALS = 2       // Default decimals for usdc that is used for withdraw
 
+// Asset id constants
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/asset-ids
+const SPOT_ASSET_OFFSET = 10000      // Spot asset ids are the index in the spot universe plus this
+const PERP_DEX_ASSET_OFFSET = 100000 // HIP-3 perp asset ids are this plus the dex index times PERP_DEX_ASSET_STRIDE plus the index in the dex universe
+const PERP_DEX_ASSET_STRIDE = 10000
+
 // Signing constants
 const HYPERLIQUID_CHAIN_ID = 1337
 const VERIFYING_CONTRACT = "0x0000000000000000000000000000000000000000"
 const ARBITRUM_CHAIN_ID = 42161
 const ARBITRUM_TESTNET_CHAIN_ID = 421614
+
+// Rate limit constants
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/rate-limits-and-user-limits
+const REST_WEIGHT_LIMIT_PER_MINUTE = 1200 // Aggregated weight of REST requests allowed per minute and IP
+const EXCHANGE_BATCH_WEIGHT_STEP = 40     // Every 40 orders or cancels in a batch add 1 to the weight
+const ADDRESS_CANCEL_EXTRA = 100000       // Cancels are allowed up to min(cap + this, 2 * cap) address requests
+
+// Nonce constants
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/nonces-and-api-wallets
+const NONCE_MAX_BEHIND = 48 * time.Hour // Nonces older than the block time minus this are rejected
+const NONCE_MAX_AHEAD = 24 * time.Hour  // Nonces newer than the block time plus this are rejected
+
+// Asset metadata constants
+const ASSET_REFRESH_MIN_INTERVAL = 10 * time.Second // An unknown coin refreshes the metadata at most once per interval
+
+// Schedule cancel constants
+const SCHEDULE_CANCEL_MIN_DELAY = 5 * time.Second // The cancel time must be at least this far in the future
@@ -43,49 +43,78 @@ func OrderWiresToOrderAction(orders []OrderWire, grouping Grouping) PlaceOrderAc
 	}
 }
 
-func OrderRequestToWire(req OrderRequest, meta map[string]AssetInfo, isSpot bool) OrderWire {
-	info := meta[req.Coin]
-	var assetId, maxDecimals int
+// assetIdToWire returns the asset id used in actions: spot assets are offset by 10000.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/asset-ids
+func assetIdToWire(assetId int, isSpot bool) int {
 	if isSpot {
-		// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/asset-ids
-		assetId = info.AssetId + 10000
+		return assetId + SPOT_ASSET_OFFSET
+	}
+	return assetId
+}
+
+// OrderRequestToWire does not validate the cloid, BulkOrders does.
+func OrderRequestToWire(req OrderRequest, meta map[string]AssetInfo, isSpot bool) OrderWire {
+	return newOrderWire(req, legacyAsset(req.Coin, meta[req.Coin], isSpot))
+}
+
+// orderRequestToWire checks that the cloid of the order is valid.
+func orderRequestToWire(req OrderRequest, asset ResolvedAsset) (OrderWire, error) {
+	if req.Cloid != "" {
+		if err := req.Cloid.Validate(); err != nil {
+			return OrderWire{}, err
+		}
+	}
+	return newOrderWire(req, asset), nil
+}
+
+func newOrderWire(req OrderRequest, asset ResolvedAsset) OrderWire {
+	maxDecimals := PERP_MAX_DECIMALS
+	if asset.IsSpot() {
 		maxDecimals = SPOT_MAX_DECIMALS
-	} else {
-		assetId = info.AssetId
-		maxDecimals = PERP_MAX_DECIMALS
 	}
 	return OrderWire{
-		Asset:      assetId,
+		Asset:      asset.AssetId,
 		IsBuy:      req.IsBuy,
-		LimitPx:    PriceToWire(req.LimitPx, maxDecimals, info.SzDecimals),
-		SizePx:     SizeToWire(req.Sz, info.SzDecimals),
+		LimitPx:    PriceToWire(req.LimitPx, maxDecimals, asset.SzDecimals),
+		SizePx:     SizeToWire(req.Sz, asset.SzDecimals),
 		ReduceOnly: req.ReduceOnly,
 		OrderType:  OrderTypeToWire(req.OrderType),
-		Cloid:      req.Cloid,
+		Cloid:      string(req.Cloid),
 	}
 }
 
+// ModifyOrderRequestToWire does not validate the cloid, BulkModifyOrders does.
 func ModifyOrderRequestToWire(req ModifyOrderRequest, meta map[string]AssetInfo, isSpot bool) ModifyOrderWire {
-	info := meta[req.Coin]
-	var assetId, maxDecimals int
-	if isSpot {
-		// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/asset-ids
-		assetId = info.AssetId + 10000
-		maxDecimals = SPOT_MAX_DECIMALS
-	} else {
-		assetId = info.AssetId
-		maxDecimals = PERP_MAX_DECIMALS
+	return newModifyOrderWire(req, legacyAsset(req.Coin, meta[req.Coin], isSpot))
+}
+
+// modifyOrderRequestToWire checks that the order to modify is identified and that its cloid is valid.
+func modifyOrderRequestToWire(req ModifyOrderRequest, asset ResolvedAsset) (ModifyOrderWire, error) {
+	if req.OrderId == 0 && req.Cloid == "" {
+		return ModifyOrderWire{}, APIError{Message: "Modify request has neither an order id nor a cloid"}
+	}
+	if req.Cloid != "" {
+		if err := req.Cloid.Validate(); err != nil {
+			return ModifyOrderWire{}, err
+		}
+	}
+	return newModifyOrderWire(req, asset), nil
+}
+
+func newModifyOrderWire(req ModifyOrderRequest, asset ResolvedAsset) ModifyOrderWire {
+	order := OrderRequest{
+		Coin:       req.Coin,
+		IsBuy:      req.IsBuy,
+		Sz:         req.Sz,
+		LimitPx:    req.LimitPx,
+		OrderType:  req.OrderType,
+		ReduceOnly: req.ReduceOnly,
+		Cloid:      req.Cloid,
 	}
 	return ModifyOrderWire{
 		OrderId: req.OrderId,
-		Order: OrderWire{
-			Asset:      assetId,
-			IsBuy:      req.IsBuy,
-			LimitPx:    PriceToWire(req.LimitPx, maxDecimals, info.SzDecimals),
-			SizePx:     SizeToWire(req.Sz, info.SzDecimals),
-			ReduceOnly: req.ReduceOnly,
-			OrderType:  OrderTypeToWire(req.OrderType),
-		},
+		Cloid:   req.Cloid,
+		Order:   newOrderWire(order, asset),
 	}
 }
 
@@ -186,6 +215,11 @@ func PriceToWire(x float64, maxDecimals, szDecimals int) string {
 	return s
 }
 
+// UsdToWire converts a USD amount to the integer micro-USD (6 decimals) used by some actions.
+func UsdToWire(x float64) int64 {
+	return int64(math.Round(x * 1e6))
+}
+
 // SizeToWire converts a size value to its string representation,
 // rounding it to exactly szDecimals decimals.
 // Integer sizes are returned without decimals.
@@ -0,0 +1,148 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Error kinds reported by the exchange. They are matched with errors.Is
+// against ExchangeError and OrderError values, e.g.
+//
+//	if errors.Is(err, hyperliquid.ErrInsufficientMargin) { ... }
+var (
+	ErrRateLimited        = errors.New("rate limited")
+	ErrInsufficientMargin = errors.New("insufficient margin")
+	ErrTickSize           = errors.New("price is not a multiple of the tick size")
+	ErrMinTradeNotional   = errors.New("order value is below the minimum")
+	ErrReduceOnly         = errors.New("reduce only order would increase position")
+	ErrPostOnlyMatch      = errors.New("post only order would have immediately matched")
+	ErrIocNoMatch         = errors.New("ioc order could not immediately match")
+	ErrOrderNotFound      = errors.New("order was never placed, already canceled, or filled")
+	ErrInvalidLeverage    = errors.New("invalid leverage value")
+)
+
+// errorKinds maps fragments of the exchange messages to the error kinds.
+var errorKinds = []struct {
+	fragment string
+	kind     error
+}{
+	{"insufficient margin", ErrInsufficientMargin},
+	{"tick size", ErrTickSize},
+	{"invalid price", ErrTickSize},
+	{"minimum value", ErrMinTradeNotional},
+	{"reduce only order would increase position", ErrReduceOnly},
+	{"post only order would have immediately matched", ErrPostOnlyMatch},
+	{"could not immediately match", ErrIocNoMatch},
+	{"never placed, already canceled, or filled", ErrOrderNotFound},
+	{"invalid leverage", ErrInvalidLeverage},
+	{"too many cumulative requests", ErrRateLimited},
+	{"rate limit", ErrRateLimited},
+}
+
+// matchErrorKind reports whether the exchange message belongs to the error kind target.
+func matchErrorKind(message string, target error) bool {
+	message = strings.ToLower(message)
+	for _, kind := range errorKinds {
+		if kind.kind == target && strings.Contains(message, kind.fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPError is returned when the API answers with a status code of 400 or greater.
+// A 429 status matches ErrRateLimited.
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrRateLimited && e.StatusCode == 429
+}
+
+// RateLimitError is returned by the RateLimiter when a request exceeds the local budget.
+// RetryAfter is zero when waiting does not help (address limit).
+type RateLimitError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited: %s", e.Reason)
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// ExchangeError is returned when the API rejects a request with {"status": "err"}.
+// Response holds the raw "response" field.
+type ExchangeError struct {
+	Message  string
+	Response json.RawMessage
+}
+
+func newExchangeError(response json.RawMessage) *ExchangeError {
+	var message string
+	if err := json.Unmarshal(response, &message); err != nil {
+		message = string(response)
+	}
+	return &ExchangeError{Message: message, Response: response}
+}
+
+func (e *ExchangeError) Error() string {
+	return e.Message
+}
+
+func (e *ExchangeError) Is(target error) bool {
+	return matchErrorKind(e.Message, target)
+}
+
+// OrderError is the error of a single order, cancel or modify in a batch.
+// Index is the position of the request in the batch.
+type OrderError struct {
+	Index   int
+	Message string
+}
+
+func (e *OrderError) Error() string {
+	return fmt.Sprintf("order %d: %s", e.Index, e.Message)
+}
+
+func (e *OrderError) Is(target error) bool {
+	return matchErrorKind(e.Message, target)
+}
+
+// DecodeError is returned when the response body cannot be decoded into the expected type.
+type DecodeError struct {
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("Unexpected response: %s: %s", e.Err, e.Body)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Err returns the errors of the orders in the batch joined together, or nil if every order succeeded.
+// Every joined error is an *OrderError.
+func (response *OrderResponse) Err() error {
+	var errs []error
+	for i, status := range response.Response.Data.Statuses {
+		if status.Error != "" {
+			errs = append(errs, &OrderError{Index: i, Message: status.Error})
+		}
+	}
+	return errors.Join(errs...)
+}
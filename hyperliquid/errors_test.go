@@ -0,0 +1,92 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrors_MakeUniversalRequest(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+		body   string
+		check  func(t *testing.T, err error)
+	}{
+		{
+			name:   "Exchange rejection",
+			status: http.StatusOK,
+			body:   `{"status":"err","response":"Invalid leverage value"}`,
+			check: func(t *testing.T, err error) {
+				var exchangeErr *ExchangeError
+				if !errors.As(err, &exchangeErr) || err.Error() != "Invalid leverage value" {
+					t.Errorf("error = %v, want ExchangeError", err)
+				}
+				if !errors.Is(err, ErrInvalidLeverage) {
+					t.Errorf("error = %v, want %v", err, ErrInvalidLeverage)
+				}
+			},
+		},
+		{
+			name:   "Too many requests",
+			status: http.StatusTooManyRequests,
+			body:   `null`,
+			check: func(t *testing.T, err error) {
+				var httpErr *HTTPError
+				if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+					t.Errorf("error = %v, want HTTPError 429", err)
+				}
+				if !errors.Is(err, ErrRateLimited) {
+					t.Errorf("error = %v, want %v", err, ErrRateLimited)
+				}
+			},
+		},
+		{
+			name:   "Unexpected body",
+			status: http.StatusOK,
+			body:   `["not", "a", "map"]`,
+			check: func(t *testing.T, err error) {
+				var decodeErr *DecodeError
+				if !errors.As(err, &decodeErr) || string(decodeErr.Body) != `["not", "a", "map"]` {
+					t.Errorf("error = %v, want DecodeError", err)
+				}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+			api := NewInfoAPI(true, WithBaseURL(server.URL))
+			_, err := api.GetAllMids()
+			tc.check(t, err)
+		})
+	}
+}
+
+func TestErrors_OrderResponse(t *testing.T) {
+	var response OrderResponse
+	body := `{"status":"ok","response":{"type":"order","data":{"statuses":[
+		{"resting":{"oid":77738308}},
+		{"error":"Insufficient margin to place order. asset=4"},
+		{"error":"Price must be divisible by tick size. asset=4"}]}}}`
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	err := response.Err()
+	if !errors.Is(err, ErrInsufficientMargin) || !errors.Is(err, ErrTickSize) {
+		t.Errorf("Err() = %v, want margin and tick size errors", err)
+	}
+	if errors.Is(err, ErrReduceOnly) {
+		t.Errorf("Err() = %v, want no reduce only error", err)
+	}
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) || orderErr.Index != 1 {
+		t.Errorf("Err() = %v, want first OrderError at index 1", err)
+	}
+}
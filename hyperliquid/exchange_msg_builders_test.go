@@ -1,8 +1,17 @@
 package hyperliquid
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 func GetEmptyExchangeAPI() *ExchangeAPI {
@@ -13,6 +22,55 @@ func GetEmptyExchangeAPI() *ExchangeAPI {
 	return exchangeAPI
 }
 
+// testPrivateKey signs the requests of the tests that run against a local server.
+const testPrivateKey = "0x0123456789012345678901234567890123456789012345678901234567890123"
+
+// testExchange is a fake API: it records the last /exchange request and answers it with Response,
+// or with a default ok response, and passes the /info requests to Info.
+type testExchange struct {
+	Request  ExchangeRequest
+	Response string
+	Info     http.HandlerFunc
+}
+
+func (exchange *testExchange) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/info" && exchange.Info != nil {
+		exchange.Info(w, r)
+		return
+	}
+	exchange.Request = ExchangeRequest{}
+	json.NewDecoder(r.Body).Decode(&exchange.Request)
+	if exchange.Response == "" {
+		w.Write([]byte(`{"status":"ok","response":{"type":"default"}}`))
+		return
+	}
+	w.Write([]byte(exchange.Response))
+}
+
+// action returns the action of the last /exchange request.
+func (exchange *testExchange) action() map[string]any {
+	action, _ := exchange.Request.Action.(map[string]any)
+	return action
+}
+
+// newTestExchangeAPI returns an ExchangeAPI signing with testPrivateKey and sending its requests to handler.
+// The metadata is seeded with snapshot if it is not nil.
+func newTestExchangeAPI(t *testing.T, handler http.Handler, snapshot *MetaSnapshot) (*ExchangeAPI, *PKeyManager) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	manager, err := NewPKeyManager(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewPKeyManager() error = %v", err)
+	}
+	exchangeAPI := NewExchangeAPI(true, WithBaseURL(server.URL))
+	exchangeAPI.SetKeyManager(manager)
+	if snapshot != nil {
+		exchangeAPI.LoadMetaSnapshot(*snapshot)
+	}
+	return exchangeAPI, manager
+}
+
 func TestExchangeAPI_BuildOrder(t *testing.T) {
 	exchangeAPI := GetEmptyExchangeAPI()
 	// input params
@@ -40,3 +98,400 @@ func TestExchangeAPI_BuildOrder(t *testing.T) {
 	}
 	t.Logf("BuildOrder() = %+v", res)
 }
+
+func TestExchangeAPI_BuildL1RequestWithVault(t *testing.T) {
+	exchangeAPI := NewExchangeAPI(true)
+	err := exchangeAPI.SetPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatalf("SetPrivateKey() error = %v", err)
+	}
+	vault := "0x1719884eb866cb12b2287399b15f7db5e7d775ea"
+	action := UpdateLeverageAction{Type: "updateLeverage", Asset: 1, IsCross: true, Leverage: 10}
+
+	request, err := exchangeAPI.WithVaultAddress(vault).buildL1Request(context.Background(), action)
+	if err != nil {
+		t.Fatalf("buildL1Request() error = %v", err)
+	}
+	if request.VaultAddress == nil || *request.VaultAddress != vault {
+		t.Errorf("VaultAddress = %v, want %v", request.VaultAddress, vault)
+	}
+	if exchangeAPI.VaultAddress() != "" {
+		t.Errorf("WithVaultAddress() modified the receiver")
+	}
+	withVault, _ := buildActionHash(action, vault, request.Nonce)
+	withoutVault, _ := buildActionHash(action, "", request.Nonce)
+	if withVault == withoutVault {
+		t.Errorf("buildActionHash() ignores the vault address")
+	}
+}
+
+func TestExchangeAPI_ApproveAgent(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"status":"ok","response":{"type":"default"}}`))
+	}))
+	defer server.Close()
+
+	exchangeAPI := NewExchangeAPI(false, WithBaseURL(server.URL))
+	err := exchangeAPI.SetPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatalf("SetPrivateKey() error = %v", err)
+	}
+	_, agent, err := exchangeAPI.ApproveAgent("")
+	if err != nil {
+		t.Fatalf("ApproveAgent() error = %v", err)
+	}
+	if agent.PublicAddressHex() == exchangeAPI.KeyManager().PublicAddressHex() {
+		t.Errorf("ApproveAgent() returned the master key")
+	}
+	action := body["action"].(map[string]any)
+	if action["agentAddress"] != agent.PublicAddressHex() {
+		t.Errorf("agentAddress = %v, want %v", action["agentAddress"], agent.PublicAddressHex())
+	}
+	if _, ok := action["agentName"]; ok {
+		t.Errorf("agentName is sent for an unnamed agent")
+	}
+	if action["hyperliquidChain"] != "Testnet" {
+		t.Errorf("hyperliquidChain = %v, want Testnet", action["hyperliquidChain"])
+	}
+}
+
+func TestExchangeAPI_Twap(t *testing.T) {
+	exchange := &testExchange{Response: `{"status":"ok","response":{"type":"twapOrder","data":{"status":{"running":{"twapId":77738308}}}}}`}
+	exchangeAPI, _ := newTestExchangeAPI(t, exchange, &MetaSnapshot{
+		Meta:     map[string]AssetInfo{"ETH": {SzDecimals: 4, AssetId: 1}},
+		SpotMeta: map[string]AssetInfo{"PURR": {SzDecimals: 0, AssetId: 0}},
+	})
+
+	res, err := exchangeAPI.TwapOrderSpot("PURR", true, 100, 30, true)
+	if err != nil {
+		t.Fatalf("TwapOrderSpot() error = %v", err)
+	}
+	if twapId, err := res.TwapId(); err != nil || twapId != 77738308 {
+		t.Errorf("TwapId() = %v, %v, want %v", twapId, err, 77738308)
+	}
+	twap := exchange.action()["twap"].(map[string]any)
+	if twap["a"] != float64(10000) || twap["s"] != "100" || twap["m"] != float64(30) || twap["t"] != true {
+		t.Errorf("twap action = %v", twap)
+	}
+
+	exchange.Response = `{"status":"ok","response":{"type":"twapCancel","data":{"status":{"error":"TWAP was never placed, already canceled, or filled."}}}}`
+	cancel, err := exchangeAPI.TwapCancel("ETH", 77738308)
+	if err != nil {
+		t.Fatalf("TwapCancel() error = %v", err)
+	}
+	if action := exchange.action(); action["a"] != float64(1) || action["t"] != float64(77738308) {
+		t.Errorf("twapCancel action = %v", action)
+	}
+	if err := cancel.Err(); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("TwapCancel().Err() = %v, want %v", err, ErrOrderNotFound)
+	}
+
+	exchange.Response = `{"status":"ok","response":{"type":"twapCancel","data":{"status":"success"}}}`
+	cancel, err = exchangeAPI.TwapCancel("ETH", 77738308)
+	if err != nil || cancel.Err() != nil || !cancel.Response.Data.Status.Success {
+		t.Errorf("TwapCancel() = %+v, %v", cancel, err)
+	}
+}
+
+func TestExchangeAPI_UsdSendSpotSend(t *testing.T) {
+	exchange := &testExchange{}
+	exchangeAPI, manager := newTestExchangeAPI(t, exchange, &MetaSnapshot{
+		Meta:     map[string]AssetInfo{},
+		SpotMeta: map[string]AssetInfo{},
+		SpotTokens: map[string]SpotTokenInfo{
+			"PURR": {Name: "PURR", Index: 1, TokenID: "0xc1fb593aeffbeb02f85e0308e9956a90", SzDecimals: 0, WeiDecimals: 5},
+		},
+	})
+	destination := "0x5e9ee1089755c3435139848e47e6635505d5a13a"
+
+	if _, err := exchangeAPI.UsdSend(destination, 12.345); err != nil {
+		t.Fatalf("UsdSend() error = %v", err)
+	}
+	action := exchange.action()
+	if action["type"] != "usdSend" || action["amount"] != "12.35" || action["destination"] != destination || action["time"] != float64(exchange.Request.Nonce) {
+		t.Errorf("usdSend action = %v", action)
+	}
+	if signer, err := RecoverSigner(exchange.Request, true); err != nil || signer != manager.PublicAddress() {
+		t.Errorf("RecoverSigner(usdSend) = %v, %v, want %v", signer, err, manager.PublicAddress())
+	}
+
+	if _, err := exchangeAPI.SpotSend(destination, "PURR", 1.234567); err != nil {
+		t.Fatalf("SpotSend() error = %v", err)
+	}
+	action = exchange.action()
+	if action["type"] != "spotSend" || action["token"] != "PURR:0xc1fb593aeffbeb02f85e0308e9956a90" || action["amount"] != "1.23457" {
+		t.Errorf("spotSend action = %v", action)
+	}
+	if signer, err := RecoverSigner(exchange.Request, true); err != nil || signer != manager.PublicAddress() {
+		t.Errorf("RecoverSigner(spotSend) = %v, %v, want %v", signer, err, manager.PublicAddress())
+	}
+
+	if _, err := exchangeAPI.SpotSend(destination, "PURR:0x00000000000000000000000000000000", 1); err == nil {
+		t.Errorf("SpotSend() with a wrong token id error = nil, want error")
+	}
+	if _, err := exchangeAPI.SpotSend(destination, "HYPE", 1); err == nil {
+		t.Errorf("SpotSend() with an unknown token error = nil, want error")
+	}
+}
+
+func TestExchangeAPI_SubAccounts(t *testing.T) {
+	sub := "0x035605fc2f24d65300227189025e90a0d947f16c"
+	exchange := &testExchange{
+		Response: `{"status":"ok","response":{"type":"createSubAccount","data":"` + sub + `"}}`,
+		Info: func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`[{"name":"grid","subAccountUser":"` + sub + `","master":"0x14dc79964da2c08b23698b3d3cc7ca32193d9955",` +
+				`"clearinghouseState":{"marginSummary":{"accountValue":"29.78001"},"withdrawable":"29.78001","assetPositions":[],"time":1733968369395},` +
+				`"spotState":{"balances":[{"coin":"USDC","token":0,"total":"0.22","hold":"0.0","entryNtl":"0.0"}]}}]`))
+		},
+	}
+	exchangeAPI, manager := newTestExchangeAPI(t, exchange, nil)
+	// subaccounts are managed by the master account even if the API trades for a subaccount
+	exchangeAPI.SetVaultAddress(sub)
+
+	created, err := exchangeAPI.CreateSubAccount("grid")
+	if err != nil || created.Response.Data != sub {
+		t.Fatalf("CreateSubAccount() = %+v, %v", created, err)
+	}
+	if exchange.Request.VaultAddress != nil {
+		t.Errorf("CreateSubAccount() sent vault address %s", *exchange.Request.VaultAddress)
+	}
+	if signer, err := RecoverSigner(exchange.Request, true); err != nil || signer != manager.PublicAddress() {
+		t.Errorf("RecoverSigner(createSubAccount) = %v, %v, want %v", signer, err, manager.PublicAddress())
+	}
+
+	exchange.Response = ""
+	if _, err := exchangeAPI.SubAccountTransfer(sub, true, 12.5); err != nil {
+		t.Fatalf("SubAccountTransfer() error = %v", err)
+	}
+	action := exchange.action()
+	if action["type"] != "subAccountTransfer" || action["subAccountUser"] != sub || action["isDeposit"] != true || action["usd"] != float64(12500000) {
+		t.Errorf("subAccountTransfer action = %v", action)
+	}
+	if exchange.Request.VaultAddress != nil {
+		t.Errorf("SubAccountTransfer() sent vault address %s", *exchange.Request.VaultAddress)
+	}
+	if exchangeAPI.VaultAddress() != sub {
+		t.Errorf("VaultAddress() = %s, want %s", exchangeAPI.VaultAddress(), sub)
+	}
+
+	subAccounts, err := exchangeAPI.infoAPI.GetSubAccounts("0x14dc79964da2c08b23698b3d3cc7ca32193d9955")
+	if err != nil || len(*subAccounts) != 1 {
+		t.Fatalf("GetSubAccounts() = %v, %v", subAccounts, err)
+	}
+	if account := (*subAccounts)[0]; account.Name != "grid" || account.UserState.Withdrawable != 29.78001 || account.UserStateSpot.Balances[0].Coin != "USDC" {
+		t.Errorf("GetSubAccounts() = %+v", account)
+	}
+}
+
+func TestExchangeAPI_Vaults(t *testing.T) {
+	vault := "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303"
+	var info map[string]any
+	exchange := &testExchange{Info: func(w http.ResponseWriter, r *http.Request) {
+		info = nil
+		json.NewDecoder(r.Body).Decode(&info)
+		if info["type"] == "userVaultEquities" {
+			w.Write([]byte(`[{"vaultAddress":"` + vault + `","equity":"742500.082809","lockedUntilTimestamp":1734825212000}]`))
+			return
+		}
+		w.Write([]byte(`{"name":"Test","vaultAddress":"` + vault + `","leader":"0x677d831aef5328190852e24f13c46cac05f984e7",` +
+			`"description":"This community-owned vault provides liquidity to Hyperliquid.",` +
+			`"portfolio":[["day",{"accountValueHistory":[[1734397526634,"3378.945"],[1734398426634,"3380.1"]],"pnlHistory":[[1734397526634,"0.0"]],"vlm":"0.0"}],` +
+			`["allTime",{"accountValueHistory":[[1700000000000,"100.0"]],"pnlHistory":[[1700000000000,"0.0"]],"vlm":"1234.5"}]],` +
+			`"apr":0.0727,"followerState":null,"leaderFraction":0.0034,"leaderCommission":0,` +
+			`"followers":[{"user":"0x005844b2ffb2e122cf4244be7dbcb4f84924907c","vaultEquity":"714491.71","pnl":"3203.11","allTimePnl":"79843.70",` +
+			`"daysFollowing":388,"vaultEntryTime":1700926145201,"lockupUntil":1734824439201}],` +
+			`"maxDistributable":94490.4,"maxWithdrawable":742.34,"isClosed":false,` +
+			`"relationship":{"type":"parent","data":{"childAddresses":["0x010461c14e146ac35fe42271bdc1134ee31c703a"]}},` +
+			`"allowDeposits":true,"alwaysCloseOnWithdraw":false}`))
+	}}
+	exchangeAPI, manager := newTestExchangeAPI(t, exchange, nil)
+	exchangeAPI.SetVaultAddress(vault)
+
+	if _, err := exchangeAPI.VaultTransfer(vault, true, 100.25); err != nil {
+		t.Fatalf("VaultTransfer() error = %v", err)
+	}
+	action := exchange.action()
+	if action["type"] != "vaultTransfer" || action["vaultAddress"] != vault || action["isDeposit"] != true || action["usd"] != float64(100250000) {
+		t.Errorf("vaultTransfer action = %v", action)
+	}
+	if exchange.Request.VaultAddress != nil {
+		t.Errorf("VaultTransfer() sent vault address %s", *exchange.Request.VaultAddress)
+	}
+
+	details, err := exchangeAPI.infoAPI.GetVaultDetails(vault, "")
+	if err != nil {
+		t.Fatalf("GetVaultDetails() error = %v", err)
+	}
+	if _, ok := info["user"]; ok || info["vaultAddress"] != vault {
+		t.Errorf("vaultDetails request = %v", info)
+	}
+	day := details.Portfolio["day"]
+	if len(day.AccountValueHistory) != 2 || day.AccountValueHistory[1] != (HistoryPoint{Time: 1734398426634, Value: 3380.1}) {
+		t.Errorf("Portfolio[day] = %+v", day)
+	}
+	if details.Portfolio["allTime"].Vlm != 1234.5 || details.Apr != 0.0727 || details.FollowerState != nil {
+		t.Errorf("GetVaultDetails() = %+v", details)
+	}
+	if follower := details.Followers[0]; follower.VaultEquity != 714491.71 || follower.LockupUntil != 1734824439201 {
+		t.Errorf("Followers[0] = %+v", follower)
+	}
+	if details.Relationship.Type != "parent" || len(details.Relationship.Data.ChildAddresses) != 1 {
+		t.Errorf("Relationship = %+v", details.Relationship)
+	}
+
+	equities, err := exchangeAPI.infoAPI.GetUserVaultEquities(manager.PublicAddressHex())
+	if err != nil || len(*equities) != 1 || (*equities)[0].Equity != 742500.082809 || (*equities)[0].LockedUntilTimestamp != 1734825212000 {
+		t.Errorf("GetUserVaultEquities() = %v, %v", equities, err)
+	}
+}
+
+func TestExchangeAPI_Builder(t *testing.T) {
+	builder := "0x8C967E73E7B15087C42A10D344CFF4C96D877F1D"
+	var info map[string]any
+	exchange := &testExchange{Info: func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&info)
+		w.Write([]byte(`10`))
+	}}
+	exchangeAPI, manager := newTestExchangeAPI(t, exchange, &MetaSnapshot{
+		Meta:     map[string]AssetInfo{"ETH": {SzDecimals: 4, AssetId: 1}},
+		SpotMeta: map[string]AssetInfo{},
+	})
+	order := OrderRequest{
+		Coin:      "ETH",
+		IsBuy:     true,
+		Sz:        0.1,
+		LimitPx:   2500,
+		OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}},
+	}
+
+	if _, err := exchangeAPI.WithBuilder(&BuilderInfo{Builder: builder, Fee: 10}).Order(order, GroupingNa); err != nil {
+		t.Fatalf("Order() error = %v", err)
+	}
+	action := exchange.action()
+	if got := action["builder"]; got == nil || got.(map[string]any)["b"] != strings.ToLower(builder) || got.(map[string]any)["f"] != float64(10) {
+		t.Errorf("order builder = %v", got)
+	}
+	if signer, err := RecoverSigner(exchange.Request, true); err != nil || signer != manager.PublicAddress() {
+		t.Errorf("RecoverSigner(order) = %v, %v, want %v", signer, err, manager.PublicAddress())
+	}
+	// the builder is hashed after the grouping
+	data, _ := msgpack.Marshal(PlaceOrderAction{Type: "order", Grouping: GroupingNa, Builder: &BuilderInfo{Builder: builder, Fee: 10}})
+	if grouping, builder := bytes.Index(data, []byte("grouping")), bytes.Index(data, []byte("builder")); grouping < 0 || builder < grouping {
+		t.Errorf("msgpack order action = %q", data)
+	}
+
+	// WithBuilder does not modify the receiver
+	if _, err := exchangeAPI.Order(order, GroupingNa); err != nil {
+		t.Fatalf("Order() error = %v", err)
+	}
+	if _, ok := exchange.action()["builder"]; ok {
+		t.Errorf("order without builder sent %v", exchange.Request.Action)
+	}
+
+	if _, err := exchangeAPI.ApproveBuilderFee(builder, "0.01%"); err != nil {
+		t.Fatalf("ApproveBuilderFee() error = %v", err)
+	}
+	action = exchange.action()
+	if action["type"] != "approveBuilderFee" || action["builder"] != strings.ToLower(builder) || action["maxFeeRate"] != "0.01%" {
+		t.Errorf("approveBuilderFee action = %v", action)
+	}
+	if signer, err := RecoverSigner(exchange.Request, true); err != nil || signer != manager.PublicAddress() {
+		t.Errorf("RecoverSigner(approveBuilderFee) = %v, %v, want %v", signer, err, manager.PublicAddress())
+	}
+
+	fee, err := exchangeAPI.infoAPI.GetMaxBuilderFee(manager.PublicAddressHex(), builder)
+	if err != nil || *fee != 10 || info["type"] != "maxBuilderFee" || info["builder"] != builder {
+		t.Errorf("GetMaxBuilderFee() = %v, %v, request %v", fee, err, info)
+	}
+}
+
+func TestExchangeAPI_SpotParity(t *testing.T) {
+	pricesDown := false
+	exchange := &testExchange{
+		Response: `{"status":"ok","response":{"type":"cancel","data":{"statuses":["success"]}}}`,
+		Info: func(w http.ResponseWriter, r *http.Request) {
+			var info InfoRequest
+			json.NewDecoder(r.Body).Decode(&info)
+			switch info.Typez {
+			case "openOrders":
+				w.Write([]byte(`[{"coin":"ETH","side":"B","limitPx":"2500.0","sz":"0.1","oid":1,"timestamp":1},` +
+					`{"coin":"@107","side":"A","limitPx":"40.0","sz":"1.0","oid":2,"timestamp":1},` +
+					`{"coin":"PURR/USDC","side":"B","limitPx":"0.1","sz":"100","oid":3,"timestamp":1}]`))
+			case "spotClearinghouseState":
+				w.Write([]byte(`{"balances":[{"coin":"USDC","token":0,"hold":"0.0","total":"10.0","entryNtl":"0.0"},` +
+					`{"coin":"HYPE","token":150,"hold":"0.5","total":"2.129","entryNtl":"80.0"}]}`))
+			case "spotMetaAndAssetCtxs":
+				if pricesDown {
+					http.Error(w, "unavailable", http.StatusInternalServerError)
+					return
+				}
+				w.Write([]byte(`[{},[{"coin":"@107","midPx":"40.0"},{"coin":"PURR/USDC","midPx":"0.2"}]]`))
+			}
+		},
+	}
+	exchangeAPI, _ := newTestExchangeAPI(t, exchange, &MetaSnapshot{
+		Meta: map[string]AssetInfo{"BTC": {SzDecimals: 5, AssetId: 0}, "ETH": {SzDecimals: 4, AssetId: 1}, "HYPE": {SzDecimals: 2, AssetId: 159}},
+		SpotMeta: map[string]AssetInfo{
+			"PURR": {SzDecimals: 0, AssetId: 0, SpotName: "PURR/USDC"},
+			"HYPE": {SzDecimals: 2, AssetId: 107, SpotName: "@107"},
+		},
+	})
+	cancelAssets := func() []float64 {
+		var assets []float64
+		for _, cancel := range exchange.action()["cancels"].([]any) {
+			assets = append(assets, cancel.(map[string]any)["a"].(float64))
+		}
+		return assets
+	}
+
+	if _, err := exchangeAPI.CancelAllOrders(); err != nil {
+		t.Fatalf("CancelAllOrders() error = %v", err)
+	}
+	if got := cancelAssets(); len(got) != 3 || got[0] != 1 || got[1] != 10107 || got[2] != 10000 {
+		t.Errorf("CancelAllOrders() assets = %v, want [1 10107 10000]", got)
+	}
+	if _, err := exchangeAPI.CancelAllOrdersByCoin("@107"); err != nil {
+		t.Fatalf("CancelAllOrdersByCoin() error = %v", err)
+	}
+	if got := cancelAssets(); len(got) != 1 || got[0] != 10107 {
+		t.Errorf("CancelAllOrdersByCoin(@107) assets = %v, want [10107]", got)
+	}
+	// a name shared by a perp and a spot token is the perp
+	if _, err := exchangeAPI.CancelOrderByOID("HYPE", 2); err != nil {
+		t.Fatalf("CancelOrderByOID() error = %v", err)
+	}
+	if got := cancelAssets(); len(got) != 1 || got[0] != 159 {
+		t.Errorf("CancelOrderByOID(HYPE) assets = %v, want [159]", got)
+	}
+
+	if _, err := exchangeAPI.BulkModifyOrders([]ModifyOrderRequest{{OrderId: 2, Coin: "HYPE", Sz: 1, LimitPx: 41, OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}}}}, true); err != nil {
+		t.Fatalf("BulkModifyOrders() error = %v", err)
+	}
+	modify := exchange.action()["modifies"].([]any)[0].(map[string]any)
+	if modify["oid"] != float64(2) || modify["order"].(map[string]any)["a"] != float64(10107) {
+		t.Errorf("BulkModifyOrders(spot) = %v", modify)
+	}
+
+	if _, err := exchangeAPI.CloseSpotBalance("HYPE"); err != nil {
+		t.Fatalf("CloseSpotBalance() error = %v", err)
+	}
+	order := exchange.action()["orders"].([]any)[0].(map[string]any)
+	if order["a"] != float64(10107) || order["b"] != false || order["s"] != "1.62" || order["p"] != "39.8" {
+		t.Errorf("CloseSpotBalance() order = %v", order)
+	}
+	if _, err := exchangeAPI.CloseSpotBalance("PURR"); err == nil {
+		t.Errorf("CloseSpotBalance() without balance error = nil, want error")
+	}
+
+	// without a market price no order is sent, rather than a sell at price 0
+	pricesDown = true
+	exchange.Request = ExchangeRequest{}
+	if _, err := exchangeAPI.CloseSpotBalance("HYPE"); err == nil {
+		t.Errorf("CloseSpotBalance() without market price error = nil, want error")
+	}
+	if exchange.Request.Action != nil {
+		t.Errorf("CloseSpotBalance() without market price sent %v", exchange.Request.Action)
+	}
+}
@@ -1,8 +1,10 @@
 package hyperliquid
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
@@ -12,24 +14,85 @@ type IExchangeAPI interface {
 	IClient
 
 	// Open orders
-	BulkOrders(requests []OrderRequest, grouping Grouping) (*OrderResponse, error)
+	BulkOrders(requests []OrderRequest, grouping Grouping, isSpot bool) (*OrderResponse, error)
+	BulkOrdersWithContext(ctx context.Context, requests []OrderRequest, grouping Grouping, isSpot bool) (*OrderResponse, error)
 	Order(request OrderRequest, grouping Grouping) (*OrderResponse, error)
+	OrderWithContext(ctx context.Context, request OrderRequest, grouping Grouping) (*OrderResponse, error)
 	MarketOrder(coin string, size float64, slippage *float64, clientOID ...string) (*OrderResponse, error)
-	LimitOrder(orderType string, coin string, size float64, px float64, isBuy bool, reduceOnly bool, clientOID ...string) (*OrderResponse, error)
+	MarketOrderWithContext(ctx context.Context, coin string, size float64, slippage *float64, clientOID ...string) (*OrderResponse, error)
+	LimitOrder(orderType string, coin string, size float64, px float64, reduceOnly bool, clientOID ...string) (*OrderResponse, error)
+	LimitOrderWithContext(ctx context.Context, orderType string, coin string, size float64, px float64, reduceOnly bool, clientOID ...string) (*OrderResponse, error)
 
 	// Order management
-	CancelOrderByOID(coin string, orderID int) (any, error)
-	CancelOrderByCloid(coin string, clientOID string) (any, error)
-	BulkCancelOrders(cancels []CancelOidWire) (any, error)
-	CancelAllOrdersByCoin(coin string) (any, error)
-	CancelAllOrders() (any, error)
+	CancelOrderByOID(coin string, orderID int64) (*OrderResponse, error)
+	CancelOrderByOIDWithContext(ctx context.Context, coin string, orderID int64) (*OrderResponse, error)
+	CancelOrderByCloid(coin string, clientOID string) (*OrderResponse, error)
+	CancelOrderByCloidWithContext(ctx context.Context, coin string, clientOID string) (*OrderResponse, error)
+	CancelOrderByCloidSpot(coin string, clientOID string) (*OrderResponse, error)
+	CancelOrderByCloidSpotWithContext(ctx context.Context, coin string, clientOID string) (*OrderResponse, error)
+	BulkCancelOrdersByCloid(cancels []CancelCloidRequest, isSpot bool) (*OrderResponse, error)
+	BulkCancelOrdersByCloidWithContext(ctx context.Context, cancels []CancelCloidRequest, isSpot bool) (*OrderResponse, error)
+	BulkModifyOrders(modifyRequests []ModifyOrderRequest, isSpot bool) (*OrderResponse, error)
+	BulkModifyOrdersWithContext(ctx context.Context, modifyRequests []ModifyOrderRequest, isSpot bool) (*OrderResponse, error)
+	ModifyOrder(modifyRequest ModifyOrderRequest, isSpot bool) (*OrderResponse, error)
+	ModifyOrderWithContext(ctx context.Context, modifyRequest ModifyOrderRequest, isSpot bool) (*OrderResponse, error)
+	BulkCancelOrders(cancels []CancelOidWire) (*OrderResponse, error)
+	BulkCancelOrdersWithContext(ctx context.Context, cancels []CancelOidWire) (*OrderResponse, error)
+	CancelAllOrdersByCoin(coin string) (*OrderResponse, error)
+	CancelAllOrdersByCoinWithContext(ctx context.Context, coin string) (*OrderResponse, error)
+	CancelAllOrders() (*OrderResponse, error)
+	CancelAllOrdersWithContext(ctx context.Context) (*OrderResponse, error)
 	ClosePosition(coin string) (*OrderResponse, error)
+	ClosePositionWithContext(ctx context.Context, coin string) (*OrderResponse, error)
+	CloseSpotBalance(token string) (*OrderResponse, error)
+	CloseSpotBalanceWithContext(ctx context.Context, token string) (*OrderResponse, error)
 
 	// Account management
 	Withdraw(destination string, amount float64) (*WithdrawResponse, error)
-	UpdateLeverage(coin string, isCross bool, leverage int) (any, error)
+	WithdrawWithContext(ctx context.Context, destination string, amount float64) (*WithdrawResponse, error)
+	UpdateLeverage(coin string, isCross bool, leverage int) (*DefaultExchangeResponse, error)
+	UpdateLeverageWithContext(ctx context.Context, coin string, isCross bool, leverage int) (*DefaultExchangeResponse, error)
+	UpdateIsolatedMargin(coin string, isBuy bool, amountUsd float64) (*DefaultExchangeResponse, error)
+	UpdateIsolatedMarginWithContext(ctx context.Context, coin string, isBuy bool, amountUsd float64) (*DefaultExchangeResponse, error)
+	TopUpIsolatedMargin(coin string, distance float64) (*DefaultExchangeResponse, error)
+	TopUpIsolatedMarginWithContext(ctx context.Context, coin string, distance float64) (*DefaultExchangeResponse, error)
 	TransferUsdClass(amount float64, toPerp bool, subaccount *string) (*DefaultExchangeResponse, error)
-	
+	TransferUsdClassWithContext(ctx context.Context, amount float64, toPerp bool, subaccount *string) (*DefaultExchangeResponse, error)
+	UsdSend(destination string, amount float64) (*DefaultExchangeResponse, error)
+	UsdSendWithContext(ctx context.Context, destination string, amount float64) (*DefaultExchangeResponse, error)
+	SpotSend(destination string, token string, amount float64) (*DefaultExchangeResponse, error)
+	SpotSendWithContext(ctx context.Context, destination string, token string, amount float64) (*DefaultExchangeResponse, error)
+	CreateSubAccount(name string) (*CreateSubAccountResponse, error)
+	CreateSubAccountWithContext(ctx context.Context, name string) (*CreateSubAccountResponse, error)
+	SubAccountModify(subAccount string, name string) (*DefaultExchangeResponse, error)
+	SubAccountModifyWithContext(ctx context.Context, subAccount string, name string) (*DefaultExchangeResponse, error)
+	SubAccountTransfer(subAccount string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error)
+	SubAccountTransferWithContext(ctx context.Context, subAccount string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error)
+	SubAccountSpotTransfer(subAccount string, isDeposit bool, token string, amount float64) (*DefaultExchangeResponse, error)
+	SubAccountSpotTransferWithContext(ctx context.Context, subAccount string, isDeposit bool, token string, amount float64) (*DefaultExchangeResponse, error)
+	VaultTransfer(vaultAddress string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error)
+	VaultTransferWithContext(ctx context.Context, vaultAddress string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error)
+	ApproveBuilderFee(builder string, maxFeeRate string) (*DefaultExchangeResponse, error)
+	ApproveBuilderFeeWithContext(ctx context.Context, builder string, maxFeeRate string) (*DefaultExchangeResponse, error)
+
+	// TWAP orders
+	TwapOrder(coin string, isBuy bool, size float64, minutes int, reduceOnly bool, randomize bool) (*TwapOrderResponse, error)
+	TwapOrderWithContext(ctx context.Context, coin string, isBuy bool, size float64, minutes int, reduceOnly bool, randomize bool) (*TwapOrderResponse, error)
+	TwapOrderSpot(coin string, isBuy bool, size float64, minutes int, randomize bool) (*TwapOrderResponse, error)
+	TwapOrderSpotWithContext(ctx context.Context, coin string, isBuy bool, size float64, minutes int, randomize bool) (*TwapOrderResponse, error)
+	TwapCancel(coin string, twapId int64) (*TwapCancelResponse, error)
+	TwapCancelWithContext(ctx context.Context, coin string, twapId int64) (*TwapCancelResponse, error)
+	TwapCancelSpot(coin string, twapId int64) (*TwapCancelResponse, error)
+	TwapCancelSpotWithContext(ctx context.Context, coin string, twapId int64) (*TwapCancelResponse, error)
+
+	// Dead man's switch
+	ScheduleCancel(time *int64) (*DefaultExchangeResponse, error)
+	ScheduleCancelWithContext(ctx context.Context, time *int64) (*DefaultExchangeResponse, error)
+
+	// Agents
+	ApproveAgent(name string) (*DefaultExchangeResponse, *PKeyManager, error)
+	ApproveAgentWithContext(ctx context.Context, name string) (*DefaultExchangeResponse, *PKeyManager, error)
+
 	// Market metadata
 	GetCachedFuturesMarketPrecision() map[string]int
 }
@@ -40,34 +103,22 @@ type ExchangeAPI struct {
 	infoAPI      *InfoAPI
 	address      string
 	baseEndpoint string
-	meta         map[string]AssetInfo
-	spotMeta     map[string]AssetInfo
+	vaultAddress string
+	builder      *BuilderInfo
 }
 
 // NewExchangeAPI creates a new default ExchangeAPI.
 // Run SetPrivateKey() and SetAccountAddress() to set the private key and account address.
-func NewExchangeAPI(isMainnet bool) *ExchangeAPI {
+// The opts are passed to NewClient and to the internal InfoAPI.
+// No request is made here: the market metadata is loaded on first use,
+// call Init() to load it upfront or LoadMetaSnapshot() to seed it from a saved copy.
+func NewExchangeAPI(isMainnet bool, opts ...ClientOption) *ExchangeAPI {
 	api := ExchangeAPI{
-		Client:       *NewClient(isMainnet),
+		Client:       *NewClient(isMainnet, opts...),
 		baseEndpoint: "/exchange",
-		infoAPI:      NewInfoAPI(isMainnet),
+		infoAPI:      NewInfoAPI(isMainnet, opts...),
 		address:      "",
 	}
-	// turn on debug mode if there is an error with /info service
-	meta, err := api.infoAPI.BuildMetaMap()
-	if err != nil {
-		api.SetDebugActive()
-		api.debug("Error building meta map: %s", err)
-	}
-	api.meta = meta
-
-	spotMeta, err := api.infoAPI.BuildSpotMetaMap()
-	if err != nil {
-		api.SetDebugActive()
-		api.debug("Error building spot meta map: %s", err)
-	}
-	api.spotMeta = spotMeta
-
 	return &api
 }
 
@@ -79,9 +130,108 @@ func (api *ExchangeAPI) Endpoint() string {
 	return api.baseEndpoint
 }
 
+// SetVaultAddress makes every L1 action (orders, cancels, modifies, leverage updates)
+// act on behalf of the vault or subaccount address. The signing key must be the vault leader,
+// the subaccount master or one of their agents. Set an empty address to trade for the account itself.
+func (api *ExchangeAPI) SetVaultAddress(address string) {
+	api.vaultAddress = address
+}
+
+// VaultAddress returns the vault or subaccount address set with SetVaultAddress.
+func (api *ExchangeAPI) VaultAddress() string {
+	return api.vaultAddress
+}
+
+// WithVaultAddress returns a copy of the API acting on behalf of the vault or subaccount address.
+// The receiver is not modified, so it can be used for a single call:
+//
+//	api.WithVaultAddress(vault).CancelAllOrders()
+func (api *ExchangeAPI) WithVaultAddress(address string) *ExchangeAPI {
+	clone := *api
+	clone.vaultAddress = address
+	return &clone
+}
+
+// WithSubAccount returns a copy of the API trading for the subaccount address, see WithVaultAddress.
+// The signing key must be the master account or one of its agents:
+//
+//	api.WithSubAccount(sub).Order(request, GroupingNa)
+func (api *ExchangeAPI) WithSubAccount(address string) *ExchangeAPI {
+	return api.WithVaultAddress(address)
+}
+
+// SetBuilder adds the builder and its fee to every order placed by the API. Set nil to remove it.
+// The user must approve the builder fee first, see ApproveBuilderFee.
+func (api *ExchangeAPI) SetBuilder(builder *BuilderInfo) {
+	api.builder = normalizeBuilder(builder)
+}
+
+// Builder returns the builder set with SetBuilder.
+func (api *ExchangeAPI) Builder() *BuilderInfo {
+	return api.builder
+}
+
+// WithBuilder returns a copy of the API adding the builder to its orders, see WithVaultAddress.
+//
+//	api.WithBuilder(&BuilderInfo{Builder: builder, Fee: 10}).Order(request, GroupingNa)
+func (api *ExchangeAPI) WithBuilder(builder *BuilderInfo) *ExchangeAPI {
+	clone := *api
+	clone.builder = normalizeBuilder(builder)
+	return &clone
+}
+
+// normalizeBuilder copies the builder with a lowercase address, as required by Hyperliquid.
+func normalizeBuilder(builder *BuilderInfo) *BuilderInfo {
+	if builder == nil {
+		return nil
+	}
+	return &BuilderInfo{
+		Builder: strings.ToLower(builder.Builder),
+		Fee:     builder.Fee,
+	}
+}
+
+// tradingAddress returns the address that holds the orders and positions:
+// the vault address if set, otherwise the account address.
+func (api *ExchangeAPI) tradingAddress() string {
+	if api.vaultAddress != "" {
+		return api.vaultAddress
+	}
+	return api.AccountAddress()
+}
+
+// buildL1Request signs the L1 action with a new nonce and wraps it in an ExchangeRequest
+// on behalf of the vault address if one is set.
+func (api *ExchangeAPI) buildL1Request(ctx context.Context, action any) (*ExchangeRequest, error) {
+	timestamp, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	v, r, s, err := api.SignL1ActionWithContext(ctx, action, timestamp)
+	if err != nil {
+		api.debug("Error signing L1 action: %s", err)
+		return nil, err
+	}
+	request := &ExchangeRequest{
+		Action:    action,
+		Nonce:     timestamp,
+		Signature: ToTypedSig(r, s, v),
+	}
+	if api.vaultAddress != "" {
+		vaultAddress := api.vaultAddress
+		request.VaultAddress = &vaultAddress
+	}
+	return request, nil
+}
+
 // Helper function to calculate the slippage price based on the market price.
 func (api *ExchangeAPI) SlippagePrice(coin string, isBuy bool, slippage float64) float64 {
-	marketPx, err := api.infoAPI.GetMartketPx(coin)
+	return api.SlippagePriceWithContext(context.Background(), coin, isBuy, slippage)
+}
+
+// SlippagePriceWithContext is the same as SlippagePrice but the request is bound to ctx.
+func (api *ExchangeAPI) SlippagePriceWithContext(ctx context.Context, coin string, isBuy bool, slippage float64) float64 {
+	marketPx, err := api.infoAPI.GetMartketPxWithContext(ctx, coin)
 	if err != nil {
 		api.debug("Error getting market price: %s", err)
 		return 0.0
@@ -91,7 +241,12 @@ func (api *ExchangeAPI) SlippagePrice(coin string, isBuy bool, slippage float64)
 
 // SlippagePriceSpot is a helper function to calculate the slippage price for a spot coin.
 func (api *ExchangeAPI) SlippagePriceSpot(coin string, isBuy bool, slippage float64) float64 {
-	marketPx, err := api.infoAPI.GetSpotMarketPx(coin)
+	return api.SlippagePriceSpotWithContext(context.Background(), coin, isBuy, slippage)
+}
+
+// SlippagePriceSpotWithContext is the same as SlippagePriceSpot but the request is bound to ctx.
+func (api *ExchangeAPI) SlippagePriceSpotWithContext(ctx context.Context, coin string, isBuy bool, slippage float64) float64 {
+	marketPx, err := api.infoAPI.GetSpotMarketPxWithContext(ctx, coin)
 	if err != nil {
 		api.debug("Error getting market price: %s", err)
 		return 0.0
@@ -110,12 +265,19 @@ func (api *ExchangeAPI) getChainParams() (string, string) {
 
 // Build bulk orders EIP712 message
 func (api *ExchangeAPI) BuildBulkOrdersEIP712(requests []OrderRequest, grouping Grouping) (apitypes.TypedData, error) {
-	var wires []OrderWire
-	for _, req := range requests {
-		wires = append(wires, OrderRequestToWire(req, api.meta, false))
+	return api.BuildBulkOrdersEIP712WithContext(context.Background(), requests, grouping)
+}
+
+// BuildBulkOrdersEIP712WithContext is the same as BuildBulkOrdersEIP712 but the metadata request is bound to ctx.
+func (api *ExchangeAPI) BuildBulkOrdersEIP712WithContext(ctx context.Context, requests []OrderRequest, grouping Grouping) (apitypes.TypedData, error) {
+	action, err := api.buildOrderAction(ctx, requests, grouping, false)
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+	timestamp, err := api.nonce()
+	if err != nil {
+		return apitypes.TypedData{}, err
 	}
-	timestamp := GetNonce()
-	action := OrderWiresToOrderAction(wires, grouping)
 	srequest, err := api.BuildEIP712Message(action, timestamp)
 	if err != nil {
 		api.debug("Error building EIP712 message: %s", err)
@@ -126,7 +288,12 @@ func (api *ExchangeAPI) BuildBulkOrdersEIP712(requests []OrderRequest, grouping
 
 // Build order EIP712 message
 func (api *ExchangeAPI) BuildOrderEIP712(request OrderRequest, grouping Grouping) (apitypes.TypedData, error) {
-	return api.BuildBulkOrdersEIP712([]OrderRequest{request}, grouping)
+	return api.BuildOrderEIP712WithContext(context.Background(), request, grouping)
+}
+
+// BuildOrderEIP712WithContext is the same as BuildOrderEIP712 but the metadata request is bound to ctx.
+func (api *ExchangeAPI) BuildOrderEIP712WithContext(ctx context.Context, request OrderRequest, grouping Grouping) (apitypes.TypedData, error) {
+	return api.BuildBulkOrdersEIP712WithContext(ctx, []OrderRequest{request}, grouping)
 }
 
 //
@@ -134,143 +301,229 @@ func (api *ExchangeAPI) BuildOrderEIP712(request OrderRequest, grouping Grouping
 //
 
 // Place orders in bulk
+// Use PairOrderResults to match the returned statuses with the requests.
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#place-an-order
 func (api *ExchangeAPI) BulkOrders(requests []OrderRequest, grouping Grouping, isSpot bool) (*OrderResponse, error) {
-	var wires []OrderWire
-	var meta map[string]AssetInfo
-	if isSpot {
-		meta = api.spotMeta
-	} else {
-		meta = api.meta
-	}
-	for _, req := range requests {
-		wires = append(wires, OrderRequestToWire(req, meta, isSpot))
+	return api.BulkOrdersWithContext(context.Background(), requests, grouping, isSpot)
+}
+
+// BulkOrdersWithContext is the same as BulkOrders but the request is bound to ctx.
+func (api *ExchangeAPI) BulkOrdersWithContext(ctx context.Context, requests []OrderRequest, grouping Grouping, isSpot bool) (*OrderResponse, error) {
+	action, err := api.buildOrderAction(ctx, requests, grouping, isSpot)
+	if err != nil {
+		return nil, err
 	}
-	timestamp := GetNonce()
-	action := OrderWiresToOrderAction(wires, grouping)
-	v, r, s, err := api.SignL1Action(action, timestamp)
+	request, err := api.buildL1Request(ctx, action)
 	if err != nil {
-		api.debug("Error signing L1 action: %s", err)
 		return nil, err
 	}
-	request := ExchangeRequest{
-		Action:    action,
-		Nonce:     timestamp,
-		Signature: ToTypedSig(r, s, v),
+	return MakeUniversalRequestWithContext[OrderResponse](ctx, api, request)
+}
+
+// buildOrderAction converts the order requests to the order action
+func (api *ExchangeAPI) buildOrderAction(ctx context.Context, requests []OrderRequest, grouping Grouping, isSpot bool) (PlaceOrderAction, error) {
+	var wires []OrderWire
+	for _, req := range requests {
+		asset, err := api.resolveAsset(ctx, req.Coin, isSpot)
+		if err != nil {
+			return PlaceOrderAction{}, err
+		}
+		wire, err := orderRequestToWire(req, asset)
+		if err != nil {
+			return PlaceOrderAction{}, err
+		}
+		wires = append(wires, wire)
 	}
-	return MakeUniversalRequest[OrderResponse](api, request)
+	action := OrderWiresToOrderAction(wires, grouping)
+	action.Builder = api.builder
+	return action, nil
 }
 
 // Cancel order(s)
+// Use PairCancelResults to match the returned statuses with the cancels.
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#cancel-order-s
 func (api *ExchangeAPI) BulkCancelOrders(cancels []CancelOidWire) (*OrderResponse, error) {
-	timestamp := GetNonce()
+	return api.BulkCancelOrdersWithContext(context.Background(), cancels)
+}
+
+// BulkCancelOrdersWithContext is the same as BulkCancelOrders but the request is bound to ctx.
+func (api *ExchangeAPI) BulkCancelOrdersWithContext(ctx context.Context, cancels []CancelOidWire) (*OrderResponse, error) {
 	action := CancelOidOrderAction{
 		Type:    "cancel",
 		Cancels: cancels,
 	}
-	v, r, s, err := api.SignL1Action(action, timestamp)
+	request, err := api.buildL1Request(ctx, action)
 	if err != nil {
-		api.debug("Error signing L1 action: %s", err)
 		return nil, err
 	}
-	request := ExchangeRequest{
-		Action:    action,
-		Nonce:     timestamp,
-		Signature: ToTypedSig(r, s, v),
-	}
-	return MakeUniversalRequest[OrderResponse](api, request)
+	return MakeUniversalRequestWithContext[OrderResponse](ctx, api, request)
 }
 
 // Bulk modify orders
+// Use PairModifyResults to match the returned statuses with the requests.
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#modify-multiple-orders
 func (api *ExchangeAPI) BulkModifyOrders(modifyRequests []ModifyOrderRequest, isSpot bool) (*OrderResponse, error) {
+	return api.BulkModifyOrdersWithContext(context.Background(), modifyRequests, isSpot)
+}
+
+// BulkModifyOrdersWithContext is the same as BulkModifyOrders but the request is bound to ctx.
+func (api *ExchangeAPI) BulkModifyOrdersWithContext(ctx context.Context, modifyRequests []ModifyOrderRequest, isSpot bool) (*OrderResponse, error) {
 	wires := []ModifyOrderWire{}
 
 	for _, req := range modifyRequests {
-		wires = append(wires, ModifyOrderRequestToWire(req, api.meta, isSpot))
+		asset, err := api.resolveAsset(ctx, req.Coin, isSpot)
+		if err != nil {
+			return nil, err
+		}
+		wire, err := modifyOrderRequestToWire(req, asset)
+		if err != nil {
+			return nil, err
+		}
+		wires = append(wires, wire)
 	}
 	action := ModifyOrderAction{
 		Type:     "batchModify",
 		Modifies: wires,
 	}
 
-	timestamp := GetNonce()
-	vVal, rVal, sVal, signErr := api.SignL1Action(action, timestamp)
-	if signErr != nil {
-		return nil, signErr
-	}
-	request := ExchangeRequest{
-		Action:    action,
-		Nonce:     timestamp,
-		Signature: ToTypedSig(rVal, sVal, vVal),
+	request, err := api.buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
 	}
-	return MakeUniversalRequest[OrderResponse](api, request)
+	return MakeUniversalRequestWithContext[OrderResponse](ctx, api, request)
+}
+
+// Modify a single order, by its order id or by its cloid if the order id is 0. See BulkModifyOrders.
+func (api *ExchangeAPI) ModifyOrder(modifyRequest ModifyOrderRequest, isSpot bool) (*OrderResponse, error) {
+	return api.ModifyOrderWithContext(context.Background(), modifyRequest, isSpot)
+}
+
+// ModifyOrderWithContext is the same as ModifyOrder but the request is bound to ctx.
+func (api *ExchangeAPI) ModifyOrderWithContext(ctx context.Context, modifyRequest ModifyOrderRequest, isSpot bool) (*OrderResponse, error) {
+	return api.BulkModifyOrdersWithContext(ctx, []ModifyOrderRequest{modifyRequest}, isSpot)
 }
 
 // Cancel exact order by Client Order Id
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#cancel-order-s-by-cloid
 func (api *ExchangeAPI) CancelOrderByCloid(coin string, clientOID string) (*OrderResponse, error) {
-	timestamp := GetNonce()
+	return api.CancelOrderByCloidWithContext(context.Background(), coin, clientOID)
+}
+
+// CancelOrderByCloidWithContext is the same as CancelOrderByCloid but the request is bound to ctx.
+func (api *ExchangeAPI) CancelOrderByCloidWithContext(ctx context.Context, coin string, clientOID string) (*OrderResponse, error) {
+	return api.BulkCancelOrdersByCloidWithContext(ctx, []CancelCloidRequest{{Coin: coin, Cloid: Cloid(clientOID)}}, false)
+}
+
+// Cancel exact spot order by Client Order Id. See CancelOrderByCloid.
+func (api *ExchangeAPI) CancelOrderByCloidSpot(coin string, clientOID string) (*OrderResponse, error) {
+	return api.CancelOrderByCloidSpotWithContext(context.Background(), coin, clientOID)
+}
+
+// CancelOrderByCloidSpotWithContext is the same as CancelOrderByCloidSpot but the request is bound to ctx.
+func (api *ExchangeAPI) CancelOrderByCloidSpotWithContext(ctx context.Context, coin string, clientOID string) (*OrderResponse, error) {
+	return api.BulkCancelOrdersByCloidWithContext(ctx, []CancelCloidRequest{{Coin: coin, Cloid: Cloid(clientOID)}}, true)
+}
+
+// Cancel orders by Client Order Id
+// Use PairCancelByCloidResults to match the returned statuses with the cancels.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#cancel-order-s-by-cloid
+func (api *ExchangeAPI) BulkCancelOrdersByCloid(cancels []CancelCloidRequest, isSpot bool) (*OrderResponse, error) {
+	return api.BulkCancelOrdersByCloidWithContext(context.Background(), cancels, isSpot)
+}
+
+// BulkCancelOrdersByCloidWithContext is the same as BulkCancelOrdersByCloid but the request is bound to ctx.
+func (api *ExchangeAPI) BulkCancelOrdersByCloidWithContext(ctx context.Context, cancels []CancelCloidRequest, isSpot bool) (*OrderResponse, error) {
+	wires := []CancelCloidWire{}
+	for _, cancel := range cancels {
+		if err := cancel.Cloid.Validate(); err != nil {
+			return nil, err
+		}
+		asset, err := api.resolveAsset(ctx, cancel.Coin, isSpot)
+		if err != nil {
+			return nil, err
+		}
+		wires = append(wires, CancelCloidWire{
+			Asset: asset.AssetId,
+			Cloid: string(cancel.Cloid),
+		})
+	}
 	action := CancelCloidOrderAction{
-		Type: "cancelByCloid",
-		Cancels: []CancelCloidWire{
-			{
-				Asset: api.meta[coin].AssetId,
-				Cloid: clientOID,
-			},
-		},
+		Type:    "cancelByCloid",
+		Cancels: wires,
 	}
-	v, r, s, err := api.SignL1Action(action, timestamp)
+	request, err := api.buildL1Request(ctx, action)
 	if err != nil {
-		api.debug("Error signing L1 action: %s", err)
 		return nil, err
 	}
-	request := ExchangeRequest{
-		Action:    action,
-		Nonce:     timestamp,
-		Signature: ToTypedSig(r, s, v),
-	}
-	return MakeUniversalRequest[OrderResponse](api, request)
+	return MakeUniversalRequestWithContext[OrderResponse](ctx, api, request)
 }
 
 // Update leverage for a coin
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#update-leverage
 func (api *ExchangeAPI) UpdateLeverage(coin string, isCross bool, leverage int) (*DefaultExchangeResponse, error) {
-	timestamp := GetNonce()
+	return api.UpdateLeverageWithContext(context.Background(), coin, isCross, leverage)
+}
+
+// UpdateLeverageWithContext is the same as UpdateLeverage but the request is bound to ctx.
+func (api *ExchangeAPI) UpdateLeverageWithContext(ctx context.Context, coin string, isCross bool, leverage int) (*DefaultExchangeResponse, error) {
+	asset, err := api.resolveAsset(ctx, coin, false)
+	if err != nil {
+		return nil, err
+	}
 	action := UpdateLeverageAction{
 		Type:     "updateLeverage",
-		Asset:    api.meta[coin].AssetId,
+		Asset:    asset.AssetId,
 		IsCross:  isCross,
 		Leverage: leverage,
 	}
-	v, r, s, err := api.SignL1Action(action, timestamp)
+	request, err := api.buildL1Request(ctx, action)
 	if err != nil {
-		api.debug("Error signing L1 action: %s", err)
 		return nil, err
 	}
-	request := ExchangeRequest{
-		Action:    action,
-		Nonce:     timestamp,
-		Signature: ToTypedSig(r, s, v),
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
+
+// Add (amountUsd > 0) or remove (amountUsd < 0) margin of an isolated position.
+// isBuy is the side of the position: true for a long, false for a short.
+// See TopUpIsolatedMargin to add margin based on the liquidation price.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#update-isolated-margin
+func (api *ExchangeAPI) UpdateIsolatedMargin(coin string, isBuy bool, amountUsd float64) (*DefaultExchangeResponse, error) {
+	return api.UpdateIsolatedMarginWithContext(context.Background(), coin, isBuy, amountUsd)
+}
+
+// UpdateIsolatedMarginWithContext is the same as UpdateIsolatedMargin but the request is bound to ctx.
+func (api *ExchangeAPI) UpdateIsolatedMarginWithContext(ctx context.Context, coin string, isBuy bool, amountUsd float64) (*DefaultExchangeResponse, error) {
+	asset, err := api.resolveAsset(ctx, coin, false)
+	if err != nil {
+		return nil, err
+	}
+	action := UpdateIsolatedMarginAction{
+		Type:  "updateIsolatedMargin",
+		Asset: asset.AssetId,
+		IsBuy: isBuy,
+		Ntli:  UsdToWire(amountUsd),
 	}
-	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+	request, err := api.buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
 }
 
 // Initiate a withdraw request
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#initiate-a-withdrawal-request
 func (api *ExchangeAPI) Withdraw(destination string, amount float64) (*WithdrawResponse, error) {
-	nonce := GetNonce()
-	action := WithdrawAction{
-		Type:        "withdraw3",
-		Destination: destination,
-		Amount:      SizeToWire(amount, USDC_SZ_DECIMALS),
-		Time:        nonce,
+	return api.WithdrawWithContext(context.Background(), destination, amount)
+}
+
+// WithdrawWithContext is the same as Withdraw but the request is bound to ctx.
+func (api *ExchangeAPI) WithdrawWithContext(ctx context.Context, destination string, amount float64) (*WithdrawResponse, error) {
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, err
 	}
-	signatureChainID, chainType := api.getChainParams()
-	action.HyperliquidChain = chainType
-	action.SignatureChainID = signatureChainID
-	v, r, s, err := api.SignWithdrawAction(action)
+	action := api.newWithdrawAction(destination, amount, nonce)
+	v, r, s, err := api.signUserSignedActionWithContext(ctx, action)
 	if err != nil {
 		api.debug("Error signing withdraw action: %s", err)
 		return nil, err
@@ -281,7 +534,19 @@ func (api *ExchangeAPI) Withdraw(destination string, amount float64) (*WithdrawR
 		Signature:    ToTypedSig(r, s, v),
 		VaultAddress: nil,
 	}
-	return MakeUniversalRequest[WithdrawResponse](api, request)
+	return MakeUniversalRequestWithContext[WithdrawResponse](ctx, api, request)
+}
+
+func (api *ExchangeAPI) newWithdrawAction(destination string, amount float64, nonce uint64) WithdrawAction {
+	signatureChainID, chainType := api.getChainParams()
+	return WithdrawAction{
+		Type:             "withdraw3",
+		Destination:      destination,
+		Amount:           SizeToWire(amount, USDC_SZ_DECIMALS),
+		Time:             nonce,
+		HyperliquidChain: chainType,
+		SignatureChainID: signatureChainID,
+	}
 }
 
 //
@@ -290,7 +555,12 @@ func (api *ExchangeAPI) Withdraw(destination string, amount float64) (*WithdrawR
 
 // Place single order
 func (api *ExchangeAPI) Order(request OrderRequest, grouping Grouping) (*OrderResponse, error) {
-	return api.BulkOrders([]OrderRequest{request}, grouping, false)
+	return api.OrderWithContext(context.Background(), request, grouping)
+}
+
+// OrderWithContext is the same as Order but the request is bound to ctx.
+func (api *ExchangeAPI) OrderWithContext(ctx context.Context, request OrderRequest, grouping Grouping) (*OrderResponse, error) {
+	return api.BulkOrdersWithContext(ctx, []OrderRequest{request}, grouping, false)
 }
 
 // Open a market order.
@@ -301,9 +571,14 @@ func (api *ExchangeAPI) Order(request OrderRequest, grouping Grouping) (*OrderRe
 //	MarketOrder("BTC", -0.1, nil) // Sell 0.1 BTC
 //	MarketOrder("BTC", 0.1, &slippage) // Buy 0.1 BTC with slippage
 func (api *ExchangeAPI) MarketOrder(coin string, size float64, slippage *float64, clientOID ...string) (*OrderResponse, error) {
+	return api.MarketOrderWithContext(context.Background(), coin, size, slippage, clientOID...)
+}
+
+// MarketOrderWithContext is the same as MarketOrder but the request is bound to ctx.
+func (api *ExchangeAPI) MarketOrderWithContext(ctx context.Context, coin string, size float64, slippage *float64, clientOID ...string) (*OrderResponse, error) {
 	slpg := GetSlippage(slippage)
 	isBuy := IsBuy(size)
-	finalPx := api.SlippagePrice(coin, isBuy, slpg)
+	finalPx := api.SlippagePriceWithContext(ctx, coin, isBuy, slpg)
 	orderType := OrderType{
 		Limit: &LimitOrderType{
 			Tif: TifIoc,
@@ -318,9 +593,9 @@ func (api *ExchangeAPI) MarketOrder(coin string, size float64, slippage *float64
 		ReduceOnly: false,
 	}
 	if len(clientOID) > 0 {
-		orderRequest.Cloid = clientOID[0]
+		orderRequest.Cloid = Cloid(clientOID[0])
 	}
-	return api.Order(orderRequest, GroupingNa)
+	return api.OrderWithContext(ctx, orderRequest, GroupingNa)
 }
 
 // MarketOrderSpot is a market order for a spot coin.
@@ -332,9 +607,14 @@ func (api *ExchangeAPI) MarketOrder(coin string, size float64, slippage *float64
 //	MarketOrderSpot("HYPE", -0.1, nil) // Sell 0.1 HYPE
 //	MarketOrderSpot("HYPE", 0.1, &slippage) // Buy 0.1 HYPE with slippage
 func (api *ExchangeAPI) MarketOrderSpot(coin string, size float64, slippage *float64) (*OrderResponse, error) {
+	return api.MarketOrderSpotWithContext(context.Background(), coin, size, slippage)
+}
+
+// MarketOrderSpotWithContext is the same as MarketOrderSpot but the request is bound to ctx.
+func (api *ExchangeAPI) MarketOrderSpotWithContext(ctx context.Context, coin string, size float64, slippage *float64) (*OrderResponse, error) {
 	slpg := GetSlippage(slippage)
 	isBuy := IsBuy(size)
-	finalPx := api.SlippagePriceSpot(coin, isBuy, slpg)
+	finalPx := api.SlippagePriceSpotWithContext(ctx, coin, isBuy, slpg)
 	orderType := OrderType{
 		Limit: &LimitOrderType{
 			Tif: TifIoc,
@@ -348,7 +628,7 @@ func (api *ExchangeAPI) MarketOrderSpot(coin string, size float64, slippage *flo
 		OrderType:  orderType,
 		ReduceOnly: false,
 	}
-	return api.OrderSpot(orderRequest, GroupingNa)
+	return api.OrderSpotWithContext(ctx, orderRequest, GroupingNa)
 }
 
 // Open a limit order.
@@ -356,6 +636,11 @@ func (api *ExchangeAPI) MarketOrderSpot(coin string, size float64, slippage *flo
 // Size determines the amount of the coin to buy/sell.
 // See the constants TifGtc, TifIoc, TifAlo.
 func (api *ExchangeAPI) LimitOrder(orderType string, coin string, size float64, px float64, reduceOnly bool, clientOID ...string) (*OrderResponse, error) {
+	return api.LimitOrderWithContext(context.Background(), orderType, coin, size, px, reduceOnly, clientOID...)
+}
+
+// LimitOrderWithContext is the same as LimitOrder but the request is bound to ctx.
+func (api *ExchangeAPI) LimitOrderWithContext(ctx context.Context, orderType string, coin string, size float64, px float64, reduceOnly bool, clientOID ...string) (*OrderResponse, error) {
 	// check if the order type is valid
 	if orderType != TifGtc && orderType != TifIoc && orderType != TifAlo {
 		return nil, APIError{Message: fmt.Sprintf("Invalid order type: %s. Available types: %s, %s, %s", orderType, TifGtc, TifIoc, TifAlo)}
@@ -374,16 +659,21 @@ func (api *ExchangeAPI) LimitOrder(orderType string, coin string, size float64,
 		ReduceOnly: reduceOnly,
 	}
 	if len(clientOID) > 0 {
-		orderRequest.Cloid = clientOID[0]
+		orderRequest.Cloid = Cloid(clientOID[0])
 	}
-	return api.Order(orderRequest, GroupingNa)
+	return api.OrderWithContext(ctx, orderRequest, GroupingNa)
 }
 
 // Close all positions for a given coin. They are closing with a market order.
 func (api *ExchangeAPI) ClosePosition(coin string) (*OrderResponse, error) {
+	return api.ClosePositionWithContext(context.Background(), coin)
+}
+
+// ClosePositionWithContext is the same as ClosePosition but the request is bound to ctx.
+func (api *ExchangeAPI) ClosePositionWithContext(ctx context.Context, coin string) (*OrderResponse, error) {
 	// Get all positions and find the one for the coin
 	// Then just make MarketOpen with the reverse size
-	state, err := api.infoAPI.GetUserState(api.AccountAddress())
+	state, err := api.infoAPI.GetUserStateWithContext(ctx, api.tradingAddress())
 	if err != nil {
 		api.debug("Error GetUserState: %s", err)
 		return nil, err
@@ -400,7 +690,7 @@ func (api *ExchangeAPI) ClosePosition(coin string) (*OrderResponse, error) {
 		size := item.Szi
 		// reverse the position to close
 		isBuy := !IsBuy(size)
-		finalPx := api.SlippagePrice(coin, isBuy, slippage)
+		finalPx := api.SlippagePriceWithContext(ctx, coin, isBuy, slippage)
 		orderType := OrderType{
 			Limit: &LimitOrderType{
 				Tif: "Ioc",
@@ -414,24 +704,99 @@ func (api *ExchangeAPI) ClosePosition(coin string) (*OrderResponse, error) {
 			OrderType:  orderType,
 			ReduceOnly: true,
 		}
-		return api.Order(orderRequest, GroupingNa)
+		return api.OrderWithContext(ctx, orderRequest, GroupingNa)
 	}
 	return nil, APIError{Message: fmt.Sprintf("No position found for %s", coin)}
 }
 
+// Sell the available spot balance of a token (e.g. "PURR") for USDC with a market order,
+// the spot equivalent of ClosePosition. The balance held by open orders is not sold
+// and the size is rounded down to the size decimals of the token.
+func (api *ExchangeAPI) CloseSpotBalance(token string) (*OrderResponse, error) {
+	return api.CloseSpotBalanceWithContext(context.Background(), token)
+}
+
+// CloseSpotBalanceWithContext is the same as CloseSpotBalance but the request is bound to ctx.
+func (api *ExchangeAPI) CloseSpotBalanceWithContext(ctx context.Context, token string) (*OrderResponse, error) {
+	if token == "USDC" {
+		return nil, APIError{Message: "USDC is the quote token and can not be sold"}
+	}
+	state, err := api.infoAPI.GetUserStateSpotWithContext(ctx, api.tradingAddress())
+	if err != nil {
+		api.debug("Error GetUserStateSpot: %s", err)
+		return nil, err
+	}
+	for _, balance := range state.Balances {
+		if token != balance.Coin {
+			continue
+		}
+		asset, err := api.resolveAsset(ctx, token, true)
+		if err != nil {
+			return nil, err
+		}
+		factor := math.Pow10(asset.SzDecimals)
+		size := math.Floor((balance.Total-balance.Hold)*factor) / factor
+		if size <= 0 {
+			return nil, APIError{Message: fmt.Sprintf("No available balance of %s to sell", token)}
+		}
+		marketPx, err := api.infoAPI.GetSpotMarketPxWithContext(ctx, token)
+		if err != nil {
+			api.debug("Error getting market price: %s", err)
+			return nil, err
+		}
+		orderRequest := OrderRequest{
+			Coin:    token,
+			IsBuy:   false,
+			Sz:      size,
+			LimitPx: CalculateSlippage(false, marketPx, GetSlippage(nil)),
+			OrderType: OrderType{
+				Limit: &LimitOrderType{
+					Tif: TifIoc,
+				},
+			},
+		}
+		return api.OrderSpotWithContext(ctx, orderRequest, GroupingNa)
+	}
+	return nil, APIError{Message: fmt.Sprintf("No balance found for %s", token)}
+}
+
 // OrderSpot places a spot order
 func (api *ExchangeAPI) OrderSpot(request OrderRequest, grouping Grouping) (*OrderResponse, error) {
-	return api.BulkOrders([]OrderRequest{request}, grouping, true)
+	return api.OrderSpotWithContext(context.Background(), request, grouping)
+}
+
+// OrderSpotWithContext is the same as OrderSpot but the request is bound to ctx.
+func (api *ExchangeAPI) OrderSpotWithContext(ctx context.Context, request OrderRequest, grouping Grouping) (*OrderResponse, error) {
+	return api.BulkOrdersWithContext(ctx, []OrderRequest{request}, grouping, true)
 }
 
 // Cancel exact order by OID
+// coin is a perp or a spot coin, use the spot market name ("@107") for tokens that are also perps.
 func (api *ExchangeAPI) CancelOrderByOID(coin string, orderID int64) (*OrderResponse, error) {
-	return api.BulkCancelOrders([]CancelOidWire{{Asset: api.meta[coin].AssetId, Oid: int(orderID)}})
+	return api.CancelOrderByOIDWithContext(context.Background(), coin, orderID)
+}
+
+// CancelOrderByOIDWithContext is the same as CancelOrderByOID but the request is bound to ctx.
+func (api *ExchangeAPI) CancelOrderByOIDWithContext(ctx context.Context, coin string, orderID int64) (*OrderResponse, error) {
+	asset, err := api.resolveAnyAsset(ctx, coin)
+	if err != nil {
+		return nil, err
+	}
+	return api.BulkCancelOrdersWithContext(ctx, []CancelOidWire{{Asset: asset.AssetId, Oid: int(orderID)}})
 }
 
 // Cancel all orders for a given coin
 func (api *ExchangeAPI) CancelAllOrdersByCoin(coin string) (*OrderResponse, error) {
-	orders, err := api.infoAPI.GetOpenOrders(api.AccountAddress())
+	return api.CancelAllOrdersByCoinWithContext(context.Background(), coin)
+}
+
+// CancelAllOrdersByCoinWithContext is the same as CancelAllOrdersByCoin but the request is bound to ctx.
+func (api *ExchangeAPI) CancelAllOrdersByCoinWithContext(ctx context.Context, coin string) (*OrderResponse, error) {
+	asset, err := api.resolveAnyAsset(ctx, coin)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := api.infoAPI.GetOpenOrdersWithContext(ctx, api.tradingAddress())
 	if err != nil {
 		api.debug("Error getting orders: %s", err)
 		return nil, err
@@ -441,14 +806,19 @@ func (api *ExchangeAPI) CancelAllOrdersByCoin(coin string) (*OrderResponse, erro
 		if coin != order.Coin {
 			continue
 		}
-		cancels = append(cancels, CancelOidWire{Asset: api.meta[coin].AssetId, Oid: int(order.Oid)})
+		cancels = append(cancels, CancelOidWire{Asset: asset.AssetId, Oid: int(order.Oid)})
 	}
-	return api.BulkCancelOrders(cancels)
+	return api.BulkCancelOrdersWithContext(ctx, cancels)
 }
 
 // Cancel all open orders
 func (api *ExchangeAPI) CancelAllOrders() (*OrderResponse, error) {
-	orders, err := api.infoAPI.GetOpenOrders(api.AccountAddress())
+	return api.CancelAllOrdersWithContext(context.Background())
+}
+
+// CancelAllOrdersWithContext is the same as CancelAllOrders but the request is bound to ctx.
+func (api *ExchangeAPI) CancelAllOrdersWithContext(ctx context.Context) (*OrderResponse, error) {
+	orders, err := api.infoAPI.GetOpenOrdersWithContext(ctx, api.tradingAddress())
 	if err != nil {
 		api.debug("Error getting orders: %s", err)
 		return nil, err
@@ -458,9 +828,13 @@ func (api *ExchangeAPI) CancelAllOrders() (*OrderResponse, error) {
 	}
 	var cancels []CancelOidWire
 	for _, order := range *orders {
-		cancels = append(cancels, CancelOidWire{Asset: api.meta[order.Coin].AssetId, Oid: int(order.Oid)})
+		asset, err := api.resolveAnyAsset(ctx, order.Coin)
+		if err != nil {
+			return nil, err
+		}
+		cancels = append(cancels, CancelOidWire{Asset: asset.AssetId, Oid: int(order.Oid)})
 	}
-	return api.BulkCancelOrders(cancels)
+	return api.BulkCancelOrdersWithContext(ctx, cancels)
 }
 
 // TransferUsdClass transfers USDC between spot and perp accounts
@@ -469,37 +843,429 @@ func (api *ExchangeAPI) CancelAllOrders() (*OrderResponse, error) {
 // subaccount: Optional subaccount address. Set to nil if not using a subaccount
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#transfer-from-spot-account-to-perp-account-and-vice-versa
 func (api *ExchangeAPI) TransferUsdClass(amount float64, toPerp bool, subaccount *string) (*DefaultExchangeResponse, error) {
-	timestamp := GetNonce()
-	signatureChainID, chainType := api.getChainParams()
+	return api.TransferUsdClassWithContext(context.Background(), amount, toPerp, subaccount)
+}
 
-	action := UsdClassTransferAction{
+// TransferUsdClassWithContext is the same as TransferUsdClass but the request is bound to ctx.
+func (api *ExchangeAPI) TransferUsdClassWithContext(ctx context.Context, amount float64, toPerp bool, subaccount *string) (*DefaultExchangeResponse, error) {
+	timestamp, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	action := api.newUsdClassTransferAction(amount, toPerp, subaccount, timestamp)
+
+	v, r, s, err := api.signUserSignedActionWithContext(ctx, action)
+	if err != nil {
+		api.debug("Error signing UsdClassTransfer action: %s", err)
+		return nil, err
+	}
+
+	request := ExchangeRequest{
+		Action:       action,
+		Nonce:        timestamp,
+		Signature:    ToTypedSig(r, s, v),
+		VaultAddress: nil,
+	}
+
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
+
+func (api *ExchangeAPI) newUsdClassTransferAction(amount float64, toPerp bool, subaccount *string, nonce uint64) UsdClassTransferAction {
+	signatureChainID, chainType := api.getChainParams()
+	return UsdClassTransferAction{
 		Type:             "usdClassTransfer",
 		Amount:           SizeToWire(amount, USDC_SZ_DECIMALS),
 		ToPerp:           toPerp,
-		Nonce:            timestamp,
+		Nonce:            nonce,
 		HyperliquidChain: chainType,
 		SignatureChainID: signatureChainID,
 		Subaccount:       subaccount,
 	}
+}
 
-	v, r, s, err := api.SignUsdClassTransferAction(action)
+// UsdSend transfers USDC from the perp balance to another address on Hyperliquid, without going through the bridge.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#core-usdc-transfer
+func (api *ExchangeAPI) UsdSend(destination string, amount float64) (*DefaultExchangeResponse, error) {
+	return api.UsdSendWithContext(context.Background(), destination, amount)
+}
+
+// UsdSendWithContext is the same as UsdSend but the request is bound to ctx.
+func (api *ExchangeAPI) UsdSendWithContext(ctx context.Context, destination string, amount float64) (*DefaultExchangeResponse, error) {
+	nonce, err := api.nonce()
 	if err != nil {
-		api.debug("Error signing UsdClassTransfer action: %s", err)
 		return nil, err
 	}
+	action := api.newUsdSendAction(destination, amount, nonce)
+	v, r, s, err := api.signUserSignedActionWithContext(ctx, action)
+	if err != nil {
+		api.debug("Error signing UsdSend action: %s", err)
+		return nil, err
+	}
+	request := ExchangeRequest{
+		Action:       action,
+		Nonce:        nonce,
+		Signature:    ToTypedSig(r, s, v),
+		VaultAddress: nil,
+	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
 
+func (api *ExchangeAPI) newUsdSendAction(destination string, amount float64, nonce uint64) UsdSendAction {
+	signatureChainID, chainType := api.getChainParams()
+	return UsdSendAction{
+		Type:             "usdSend",
+		HyperliquidChain: chainType,
+		SignatureChainID: signatureChainID,
+		Destination:      destination,
+		Amount:           SizeToWire(amount, USDC_SZ_DECIMALS),
+		Time:             nonce,
+	}
+}
+
+// SpotSend transfers a spot token from the spot balance to another address on Hyperliquid.
+// token is the token name (e.g. "PURR" or "USDC"), the amount is rounded to the wei decimals of the token.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#core-spot-transfer
+func (api *ExchangeAPI) SpotSend(destination string, token string, amount float64) (*DefaultExchangeResponse, error) {
+	return api.SpotSendWithContext(context.Background(), destination, token, amount)
+}
+
+// SpotSendWithContext is the same as SpotSend but the request is bound to ctx.
+func (api *ExchangeAPI) SpotSendWithContext(ctx context.Context, destination string, token string, amount float64) (*DefaultExchangeResponse, error) {
+	info, err := api.infoAPI.assets.SpotToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	action := api.newSpotSendAction(destination, info, amount, nonce)
+	v, r, s, err := api.signUserSignedActionWithContext(ctx, action)
+	if err != nil {
+		api.debug("Error signing SpotSend action: %s", err)
+		return nil, err
+	}
 	request := ExchangeRequest{
 		Action:       action,
-		Nonce:        timestamp,
+		Nonce:        nonce,
 		Signature:    ToTypedSig(r, s, v),
 		VaultAddress: nil,
 	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
+
+func (api *ExchangeAPI) newSpotSendAction(destination string, token SpotTokenInfo, amount float64, nonce uint64) SpotSendAction {
+	signatureChainID, chainType := api.getChainParams()
+	return SpotSendAction{
+		Type:             "spotSend",
+		HyperliquidChain: chainType,
+		SignatureChainID: signatureChainID,
+		Destination:      destination,
+		Token:            token.Wire(),
+		Amount:           SizeToWire(amount, token.WeiDecimals),
+		Time:             nonce,
+	}
+}
+
+// Create a subaccount of the account. Use Response.Data of the response to get its address.
+// Subaccounts are managed by the master account: the request is never sent on behalf of the vault address.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#create-subaccount
+func (api *ExchangeAPI) CreateSubAccount(name string) (*CreateSubAccountResponse, error) {
+	return api.CreateSubAccountWithContext(context.Background(), name)
+}
+
+// CreateSubAccountWithContext is the same as CreateSubAccount but the request is bound to ctx.
+func (api *ExchangeAPI) CreateSubAccountWithContext(ctx context.Context, name string) (*CreateSubAccountResponse, error) {
+	action := CreateSubAccountAction{
+		Type: "createSubAccount",
+		Name: name,
+	}
+	request, err := api.WithVaultAddress("").buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[CreateSubAccountResponse](ctx, api, request)
+}
+
+// Rename a subaccount of the account.
+func (api *ExchangeAPI) SubAccountModify(subAccount string, name string) (*DefaultExchangeResponse, error) {
+	return api.SubAccountModifyWithContext(context.Background(), subAccount, name)
+}
+
+// SubAccountModifyWithContext is the same as SubAccountModify but the request is bound to ctx.
+func (api *ExchangeAPI) SubAccountModifyWithContext(ctx context.Context, subAccount string, name string) (*DefaultExchangeResponse, error) {
+	action := SubAccountModifyAction{
+		Type:           "subAccountModify",
+		SubAccountUser: subAccount,
+		Name:           name,
+	}
+	request, err := api.WithVaultAddress("").buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
+
+// Transfer USDC between the perp balances of the account and its subaccount.
+// isDeposit: true to transfer from the account to the subaccount, false for the other way.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#transfer-to-from-subaccount
+func (api *ExchangeAPI) SubAccountTransfer(subAccount string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error) {
+	return api.SubAccountTransferWithContext(context.Background(), subAccount, isDeposit, usd)
+}
+
+// SubAccountTransferWithContext is the same as SubAccountTransfer but the request is bound to ctx.
+func (api *ExchangeAPI) SubAccountTransferWithContext(ctx context.Context, subAccount string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error) {
+	action := SubAccountTransferAction{
+		Type:           "subAccountTransfer",
+		SubAccountUser: subAccount,
+		IsDeposit:      isDeposit,
+		Usd:            UsdToWire(usd),
+	}
+	request, err := api.WithVaultAddress("").buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
+
+// Transfer a spot token between the spot balances of the account and its subaccount.
+// token is the token name (e.g. "PURR" or "USDC"), see SpotSend.
+func (api *ExchangeAPI) SubAccountSpotTransfer(subAccount string, isDeposit bool, token string, amount float64) (*DefaultExchangeResponse, error) {
+	return api.SubAccountSpotTransferWithContext(context.Background(), subAccount, isDeposit, token, amount)
+}
+
+// SubAccountSpotTransferWithContext is the same as SubAccountSpotTransfer but the request is bound to ctx.
+func (api *ExchangeAPI) SubAccountSpotTransferWithContext(ctx context.Context, subAccount string, isDeposit bool, token string, amount float64) (*DefaultExchangeResponse, error) {
+	info, err := api.infoAPI.assets.SpotToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	action := SubAccountSpotTransferAction{
+		Type:           "subAccountSpotTransfer",
+		SubAccountUser: subAccount,
+		IsDeposit:      isDeposit,
+		Token:          info.Wire(),
+		Amount:         SizeToWire(amount, info.WeiDecimals),
+	}
+	request, err := api.WithVaultAddress("").buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
+
+// Deposit USDC to a vault (isDeposit true) or withdraw from it (isDeposit false).
+// Withdrawals are rejected during the lockup period, see LockupUntil in InfoAPI.GetVaultDetails.
+// The request is sent by the account itself, never on behalf of the vault address.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#deposit-or-withdraw-from-a-vault
+func (api *ExchangeAPI) VaultTransfer(vaultAddress string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error) {
+	return api.VaultTransferWithContext(context.Background(), vaultAddress, isDeposit, usd)
+}
+
+// VaultTransferWithContext is the same as VaultTransfer but the request is bound to ctx.
+func (api *ExchangeAPI) VaultTransferWithContext(ctx context.Context, vaultAddress string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error) {
+	action := VaultTransferAction{
+		Type:         "vaultTransfer",
+		VaultAddress: vaultAddress,
+		IsDeposit:    isDeposit,
+		Usd:          UsdToWire(usd),
+	}
+	request, err := api.WithVaultAddress("").buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
+
+// Place a TWAP order: size is split in slices executed every 30 seconds over minutes.
+// randomize makes the slices sizes random.
+// Use TwapId() on the response to get the id of the TWAP.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#place-a-twap-order
+func (api *ExchangeAPI) TwapOrder(coin string, isBuy bool, size float64, minutes int, reduceOnly bool, randomize bool) (*TwapOrderResponse, error) {
+	return api.TwapOrderWithContext(context.Background(), coin, isBuy, size, minutes, reduceOnly, randomize)
+}
+
+// TwapOrderWithContext is the same as TwapOrder but the request is bound to ctx.
+func (api *ExchangeAPI) TwapOrderWithContext(ctx context.Context, coin string, isBuy bool, size float64, minutes int, reduceOnly bool, randomize bool) (*TwapOrderResponse, error) {
+	return api.twapOrder(ctx, coin, isBuy, size, minutes, reduceOnly, randomize, false)
+}
+
+// Place a TWAP order on a spot market. See TwapOrder.
+func (api *ExchangeAPI) TwapOrderSpot(coin string, isBuy bool, size float64, minutes int, randomize bool) (*TwapOrderResponse, error) {
+	return api.TwapOrderSpotWithContext(context.Background(), coin, isBuy, size, minutes, randomize)
+}
+
+// TwapOrderSpotWithContext is the same as TwapOrderSpot but the request is bound to ctx.
+func (api *ExchangeAPI) TwapOrderSpotWithContext(ctx context.Context, coin string, isBuy bool, size float64, minutes int, randomize bool) (*TwapOrderResponse, error) {
+	return api.twapOrder(ctx, coin, isBuy, size, minutes, false, randomize, true)
+}
 
-	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+func (api *ExchangeAPI) twapOrder(ctx context.Context, coin string, isBuy bool, size float64, minutes int, reduceOnly bool, randomize bool, isSpot bool) (*TwapOrderResponse, error) {
+	asset, err := api.resolveAsset(ctx, coin, isSpot)
+	if err != nil {
+		return nil, err
+	}
+	action := TwapOrderAction{
+		Type: "twapOrder",
+		Twap: TwapWire{
+			Asset:      asset.AssetId,
+			IsBuy:      isBuy,
+			Size:       SizeToWire(size, asset.SzDecimals),
+			ReduceOnly: reduceOnly,
+			Minutes:    minutes,
+			Randomize:  randomize,
+		},
+	}
+	request, err := api.buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[TwapOrderResponse](ctx, api, request)
+}
+
+// Cancel a running TWAP order by its id.
+// Use Err() on the response to check that the TWAP was canceled.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#cancel-a-twap-order
+func (api *ExchangeAPI) TwapCancel(coin string, twapId int64) (*TwapCancelResponse, error) {
+	return api.TwapCancelWithContext(context.Background(), coin, twapId)
+}
+
+// TwapCancelWithContext is the same as TwapCancel but the request is bound to ctx.
+func (api *ExchangeAPI) TwapCancelWithContext(ctx context.Context, coin string, twapId int64) (*TwapCancelResponse, error) {
+	return api.twapCancel(ctx, coin, twapId, false)
+}
+
+// Cancel a running TWAP order on a spot market. See TwapCancel.
+func (api *ExchangeAPI) TwapCancelSpot(coin string, twapId int64) (*TwapCancelResponse, error) {
+	return api.TwapCancelSpotWithContext(context.Background(), coin, twapId)
+}
+
+// TwapCancelSpotWithContext is the same as TwapCancelSpot but the request is bound to ctx.
+func (api *ExchangeAPI) TwapCancelSpotWithContext(ctx context.Context, coin string, twapId int64) (*TwapCancelResponse, error) {
+	return api.twapCancel(ctx, coin, twapId, true)
+}
+
+func (api *ExchangeAPI) twapCancel(ctx context.Context, coin string, twapId int64, isSpot bool) (*TwapCancelResponse, error) {
+	asset, err := api.resolveAsset(ctx, coin, isSpot)
+	if err != nil {
+		return nil, err
+	}
+	action := TwapCancelAction{
+		Type:   "twapCancel",
+		Asset:  asset.AssetId,
+		TwapId: twapId,
+	}
+	request, err := api.buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[TwapCancelResponse](ctx, api, request)
+}
+
+// Schedule a cancel of all open orders at time (in milliseconds), a dead man's switch.
+// time must be at least 5 seconds in the future, nil removes the scheduled cancel.
+// Hyperliquid limits the number of triggered cancels per day, see RunScheduleCancelHeartbeat to keep it armed.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#schedule-cancel-dead-mans-switch
+func (api *ExchangeAPI) ScheduleCancel(time *int64) (*DefaultExchangeResponse, error) {
+	return api.ScheduleCancelWithContext(context.Background(), time)
+}
+
+// ScheduleCancelWithContext is the same as ScheduleCancel but the request is bound to ctx.
+func (api *ExchangeAPI) ScheduleCancelWithContext(ctx context.Context, time *int64) (*DefaultExchangeResponse, error) {
+	action := ScheduleCancelAction{
+		Type: "scheduleCancel",
+		Time: time,
+	}
+	request, err := api.buildL1Request(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+}
+
+// ApproveAgent generates a new API wallet (agent) and approves it with the master key of the client.
+// The returned PKeyManager holds the private key of the agent: store it safely, it cannot be recovered.
+// name is optional. Approving a new agent with the name of an existing one replaces it,
+// which allows to rotate the key of a bot. Unnamed agents replace the previous unnamed agent.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#approve-an-api-wallet
+func (api *ExchangeAPI) ApproveAgent(name string) (*DefaultExchangeResponse, *PKeyManager, error) {
+	return api.ApproveAgentWithContext(context.Background(), name)
+}
+
+// ApproveAgentWithContext is the same as ApproveAgent but the request is bound to ctx.
+func (api *ExchangeAPI) ApproveAgentWithContext(ctx context.Context, name string) (*DefaultExchangeResponse, *PKeyManager, error) {
+	agent, err := GeneratePKeyManager()
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, nil, err
+	}
+	signatureChainID, chainType := api.getChainParams()
+	action := ApproveAgentAction{
+		Type:             "approveAgent",
+		HyperliquidChain: chainType,
+		SignatureChainID: signatureChainID,
+		AgentAddress:     agent.PublicAddressHex(),
+		AgentName:        name,
+		Nonce:            nonce,
+	}
+	v, r, s, err := api.signUserSignedActionWithContext(ctx, action)
+	if err != nil {
+		api.debug("Error signing approveAgent action: %s", err)
+		return nil, nil, err
+	}
+	request := ExchangeRequest{
+		Action:    action,
+		Nonce:     nonce,
+		Signature: ToTypedSig(r, s, v),
+	}
+	response, err := MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, agent, nil
+}
+
+// Approve a maximum fee rate for a builder, e.g. "0.01%".
+// Must be signed by the account itself, not by an agent.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#approve-a-builder-fee
+func (api *ExchangeAPI) ApproveBuilderFee(builder string, maxFeeRate string) (*DefaultExchangeResponse, error) {
+	return api.ApproveBuilderFeeWithContext(context.Background(), builder, maxFeeRate)
+}
+
+// ApproveBuilderFeeWithContext is the same as ApproveBuilderFee but the request is bound to ctx.
+func (api *ExchangeAPI) ApproveBuilderFeeWithContext(ctx context.Context, builder string, maxFeeRate string) (*DefaultExchangeResponse, error) {
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	signatureChainID, chainType := api.getChainParams()
+	action := ApproveBuilderFeeAction{
+		Type:             "approveBuilderFee",
+		HyperliquidChain: chainType,
+		SignatureChainID: signatureChainID,
+		MaxFeeRate:       maxFeeRate,
+		Builder:          strings.ToLower(builder),
+		Nonce:            nonce,
+	}
+	v, r, s, err := api.signUserSignedActionWithContext(ctx, action)
+	if err != nil {
+		api.debug("Error signing approveBuilderFee action: %s", err)
+		return nil, err
+	}
+	request := ExchangeRequest{
+		Action:    action,
+		Nonce:     nonce,
+		Signature: ToTypedSig(r, s, v),
+	}
+	return MakeUniversalRequestWithContext[DefaultExchangeResponse](ctx, api, request)
 }
 
 // GetCachedFuturesMarketPrecision returns the cached market precision (szDecimals) for perpetual futures.
-// This uses the metadata that was already fetched during ExchangeAPI initialization, avoiding additional API calls.
+// This uses the metadata that was already loaded by Init(), LoadMetaSnapshot() or a previous request,
+// avoiding additional API calls. The map is empty if no metadata is loaded yet.
 // The actual minimum lot size step can be calculated as 1/10^szDecimals.
 //
 // Example:
@@ -508,8 +1274,9 @@ func (api *ExchangeAPI) TransferUsdClass(amount float64, toPerp bool, subaccount
 //
 // This function only returns data for perpetual futures, not spot markets.
 func (api *ExchangeAPI) GetCachedFuturesMarketPrecision() map[string]int {
-	res := make(map[string]int, len(api.meta))
-	for asset, info := range api.meta {
+	meta := api.MetaSnapshot().Meta
+	res := make(map[string]int, len(meta))
+	for asset, info := range meta {
 		res[asset] = info.SzDecimals
 	}
 	return res
@@ -1,12 +1,22 @@
 package hyperliquid
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 func (api *ExchangeAPI) Sign(request *SignRequest) (byte, [32]byte, [32]byte, error) {
-	signer := NewSigner(api.keyManager)
-	v, r, s, err := signer.Sign(request)
+	return api.SignWithContext(context.Background(), request)
+}
+
+// SignWithContext is the same as Sign but ctx is passed to the Signer.
+func (api *ExchangeAPI) SignWithContext(ctx context.Context, request *SignRequest) (byte, [32]byte, [32]byte, error) {
+	if api.signer == nil {
+		return 0, [32]byte{}, [32]byte{}, APIError{Message: "Signer not set"}
+	}
+	v, r, s, err := signRequest(ctx, api.signer, request)
 	if err != nil {
 		api.debug("Error SignInner: %s", err)
 		return 0, [32]byte{}, [32]byte{}, err
@@ -15,39 +25,98 @@ func (api *ExchangeAPI) Sign(request *SignRequest) (byte, [32]byte, [32]byte, er
 }
 
 func (api *ExchangeAPI) SignUserSignableAction(action any, payloadTypes []apitypes.Type, primaryType string) (byte, [32]byte, [32]byte, error) {
+	return api.SignUserSignableActionWithContext(context.Background(), action, payloadTypes, primaryType)
+}
+
+// SignUserSignableActionWithContext is the same as SignUserSignableAction but ctx is passed to the Signer.
+func (api *ExchangeAPI) SignUserSignableActionWithContext(ctx context.Context, action any, payloadTypes []apitypes.Type, primaryType string) (byte, [32]byte, [32]byte, error) {
 	message, err := StructToMap(action)
 	if err != nil {
 		return 0, [32]byte{}, [32]byte{}, err
 	}
+	return api.SignWithContext(ctx, buildUserSignableMessage(message, payloadTypes, primaryType, api.IsMainnet()))
+}
+
+// buildUserSignableMessage builds the typed data of an action that is already converted to a map.
+func buildUserSignableMessage(message map[string]any, payloadTypes []apitypes.Type, primaryType string, isMainnet bool) *SignRequest {
+	signatureChainID, _ := message["signatureChainId"].(string)
 	// Remove unnecessary fields for signing
 	delete(message, "type")
 	delete(message, "signatureChainId")
 
-	signRequest := &SignRequest{
-		DomainName:  "HyperliquidSignTransaction",
-		PrimaryType: primaryType,
-		DType:       payloadTypes,
-		DTypeMsg:    message,
-		IsMainNet:   api.IsMainnet(),
+	return &SignRequest{
+		DomainName:       "HyperliquidSignTransaction",
+		PrimaryType:      primaryType,
+		DType:            payloadTypes,
+		DTypeMsg:         message,
+		IsMainNet:        isMainnet,
+		SignatureChainID: signatureChainID,
 	}
-	return api.Sign(signRequest)
+}
+
+// BuildUserSignedEIP712Message builds the typed data of a user-signed action:
+// WithdrawAction, UsdClassTransferAction, UsdSendAction, SpotSendAction, ApproveAgentAction or ApproveBuilderFeeAction.
+func (api *ExchangeAPI) BuildUserSignedEIP712Message(action any) (*SignRequest, error) {
+	return buildUserSignedEIP712Message(action, api.IsMainnet())
+}
+
+func buildUserSignedEIP712Message(action any, isMainnet bool) (*SignRequest, error) {
+	switch action := action.(type) {
+	case WithdrawAction:
+		return buildWithdrawMessage(action, isMainnet)
+	case UsdClassTransferAction:
+		return buildUsdClassTransferMessage(action, isMainnet)
+	case UsdSendAction:
+		return buildUsdSendMessage(action, isMainnet)
+	case SpotSendAction:
+		return buildSpotSendMessage(action, isMainnet)
+	case ApproveAgentAction:
+		return buildApproveAgentMessage(action, isMainnet)
+	case ApproveBuilderFeeAction:
+		return buildApproveBuilderFeeMessage(action, isMainnet)
+	default:
+		return nil, fmt.Errorf("unsupported user-signed action: %T", action)
+	}
+}
+
+func (api *ExchangeAPI) signUserSignedAction(action any) (byte, [32]byte, [32]byte, error) {
+	return api.signUserSignedActionWithContext(context.Background(), action)
+}
+
+func (api *ExchangeAPI) signUserSignedActionWithContext(ctx context.Context, action any) (byte, [32]byte, [32]byte, error) {
+	srequest, err := api.BuildUserSignedEIP712Message(action)
+	if err != nil {
+		return 0, [32]byte{}, [32]byte{}, err
+	}
+	return api.SignWithContext(ctx, srequest)
 }
 
 func (api *ExchangeAPI) SignL1Action(action any, timestamp uint64) (byte, [32]byte, [32]byte, error) {
+	return api.SignL1ActionWithContext(context.Background(), action, timestamp)
+}
+
+// SignL1ActionWithContext is the same as SignL1Action but ctx is passed to the Signer.
+func (api *ExchangeAPI) SignL1ActionWithContext(ctx context.Context, action any, timestamp uint64) (byte, [32]byte, [32]byte, error) {
 	srequest, err := api.BuildEIP712Message(action, timestamp)
 	if err != nil {
 		api.debug("Error building EIP712 message: %s", err)
 		return 0, [32]byte{}, [32]byte{}, err
 	}
-	return api.Sign(srequest)
+	return api.SignWithContext(ctx, srequest)
 }
 
+// BuildEIP712Message builds the typed data of an L1 action.
+// The vault address set with SetVaultAddress is part of the signed hash.
 func (api *ExchangeAPI) BuildEIP712Message(action any, timestamp uint64) (*SignRequest, error) {
-	hash, err := buildActionHash(action, "", timestamp)
+	return buildL1EIP712Message(action, api.vaultAddress, timestamp, api.IsMainnet())
+}
+
+func buildL1EIP712Message(action any, vaultAddress string, timestamp uint64, isMainnet bool) (*SignRequest, error) {
+	hash, err := buildActionHash(action, vaultAddress, timestamp)
 	if err != nil {
 		return nil, err
 	}
-	message := buildMessage(hash.Bytes(), api.IsMainnet())
+	message := buildMessage(hash.Bytes(), isMainnet)
 	srequest := &SignRequest{
 		DomainName:  "Exchange",
 		PrimaryType: "Agent",
@@ -62,12 +131,16 @@ func (api *ExchangeAPI) BuildEIP712Message(action any, timestamp uint64) (*SignR
 			},
 		},
 		DTypeMsg:  message,
-		IsMainNet: api.IsMainnet(),
+		IsMainNet: isMainnet,
 	}
 	return srequest, nil
 }
 
 func (api *ExchangeAPI) SignWithdrawAction(action WithdrawAction) (byte, [32]byte, [32]byte, error) {
+	return api.signUserSignedAction(action)
+}
+
+func buildWithdrawMessage(action WithdrawAction, isMainnet bool) (*SignRequest, error) {
 	types := []apitypes.Type{
 		{
 			Name: "hyperliquidChain",
@@ -86,10 +159,14 @@ func (api *ExchangeAPI) SignWithdrawAction(action WithdrawAction) (byte, [32]byt
 			Type: "uint64",
 		},
 	}
-	return api.SignUserSignableAction(action, types, "HyperliquidTransaction:Withdraw")
+	return buildUserSignedMessage(action, types, "HyperliquidTransaction:Withdraw", isMainnet)
 }
 
 func (api *ExchangeAPI) SignUsdClassTransferAction(action UsdClassTransferAction) (byte, [32]byte, [32]byte, error) {
+	return api.signUserSignedAction(action)
+}
+
+func buildUsdClassTransferMessage(action UsdClassTransferAction, isMainnet bool) (*SignRequest, error) {
 	types := []apitypes.Type{
 		{
 			Name: "hyperliquidChain",
@@ -114,5 +191,127 @@ func (api *ExchangeAPI) SignUsdClassTransferAction(action UsdClassTransferAction
 			Type: "string",
 		})
 	}
-	return api.SignUserSignableAction(action, types, "HyperliquidTransaction:UsdClassTransfer")
+	return buildUserSignedMessage(action, types, "HyperliquidTransaction:UsdClassTransfer", isMainnet)
+}
+
+func (api *ExchangeAPI) SignUsdSendAction(action UsdSendAction) (byte, [32]byte, [32]byte, error) {
+	return api.signUserSignedAction(action)
+}
+
+func buildUsdSendMessage(action UsdSendAction, isMainnet bool) (*SignRequest, error) {
+	types := []apitypes.Type{
+		{
+			Name: "hyperliquidChain",
+			Type: "string",
+		},
+		{
+			Name: "destination",
+			Type: "string",
+		},
+		{
+			Name: "amount",
+			Type: "string",
+		},
+		{
+			Name: "time",
+			Type: "uint64",
+		},
+	}
+	return buildUserSignedMessage(action, types, "HyperliquidTransaction:UsdSend", isMainnet)
+}
+
+func (api *ExchangeAPI) SignSpotSendAction(action SpotSendAction) (byte, [32]byte, [32]byte, error) {
+	return api.signUserSignedAction(action)
+}
+
+func buildSpotSendMessage(action SpotSendAction, isMainnet bool) (*SignRequest, error) {
+	types := []apitypes.Type{
+		{
+			Name: "hyperliquidChain",
+			Type: "string",
+		},
+		{
+			Name: "destination",
+			Type: "string",
+		},
+		{
+			Name: "token",
+			Type: "string",
+		},
+		{
+			Name: "amount",
+			Type: "string",
+		},
+		{
+			Name: "time",
+			Type: "uint64",
+		},
+	}
+	return buildUserSignedMessage(action, types, "HyperliquidTransaction:SpotSend", isMainnet)
+}
+
+func (api *ExchangeAPI) SignApproveAgentAction(action ApproveAgentAction) (byte, [32]byte, [32]byte, error) {
+	return api.signUserSignedAction(action)
+}
+
+func buildApproveAgentMessage(action ApproveAgentAction, isMainnet bool) (*SignRequest, error) {
+	types := []apitypes.Type{
+		{
+			Name: "hyperliquidChain",
+			Type: "string",
+		},
+		{
+			Name: "agentAddress",
+			Type: "address",
+		},
+		{
+			Name: "agentName",
+			Type: "string",
+		},
+		{
+			Name: "nonce",
+			Type: "uint64",
+		},
+	}
+	message, err := StructToMap(action)
+	if err != nil {
+		return nil, err
+	}
+	// An unnamed agent is signed with an empty name but sent without the field
+	message["agentName"] = action.AgentName
+	return buildUserSignableMessage(message, types, "HyperliquidTransaction:ApproveAgent", isMainnet), nil
+}
+
+func (api *ExchangeAPI) SignApproveBuilderFeeAction(action ApproveBuilderFeeAction) (byte, [32]byte, [32]byte, error) {
+	return api.signUserSignedAction(action)
+}
+
+func buildApproveBuilderFeeMessage(action ApproveBuilderFeeAction, isMainnet bool) (*SignRequest, error) {
+	types := []apitypes.Type{
+		{
+			Name: "hyperliquidChain",
+			Type: "string",
+		},
+		{
+			Name: "maxFeeRate",
+			Type: "string",
+		},
+		{
+			Name: "builder",
+			Type: "address",
+		},
+		{
+			Name: "nonce",
+			Type: "uint64",
+		},
+	}
+	return buildUserSignedMessage(action, types, "HyperliquidTransaction:ApproveBuilderFee", isMainnet)
+}
+
+func buildUserSignedMessage(action any, payloadTypes []apitypes.Type, primaryType string, isMainnet bool) (*SignRequest, error) {
+	message, err := StructToMap(action)
+	if err != nil {
+		return nil, err
+	}
+	return buildUserSignableMessage(message, payloadTypes, primaryType, isMainnet), nil
 }
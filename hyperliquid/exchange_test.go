@@ -1,6 +1,7 @@
 package hyperliquid
 
 import (
+	"context"
 	"log"
 	"math"
 	"os"
@@ -50,7 +51,11 @@ func TestExchangeAPI_isMainnet(t *testing.T) {
 
 func TestExchageAPI_TestMetaIsNotEmpty(t *testing.T) {
 	exchangeAPI := GetExchangeAPI()
-	meta := exchangeAPI.meta
+	err := exchangeAPI.Init(context.Background())
+	if err != nil {
+		t.Errorf("Init() error = %v", err)
+	}
+	meta := exchangeAPI.MetaSnapshot().Meta
 	if meta == nil {
 		t.Errorf("Meta() = %v, want not nil", meta)
 	}
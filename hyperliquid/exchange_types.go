@@ -26,6 +26,50 @@ type AssetInfo struct {
 	SpotName    string // for spot asset (e.g. "@107")
 }
 
+// SpotTokenInfo describes a spot token, as opposed to AssetInfo that describes a market.
+type SpotTokenInfo struct {
+	Name        string
+	Index       int
+	TokenID     string
+	SzDecimals  int
+	WeiDecimals int
+}
+
+// Wire returns the token as used in spot transfers, e.g. "PURR:0xc1fb593aeffbeb02f85e0308e9956a90".
+func (token SpotTokenInfo) Wire() string {
+	return token.Name + ":" + token.TokenID
+}
+
+// MarketType is the kind of market of a ResolvedAsset.
+type MarketType string
+
+const (
+	MarketTypePerp MarketType = "perp"
+	MarketTypeSpot MarketType = "spot"
+)
+
+// ResolvedAsset describes a market as found by the AssetResolver.
+type ResolvedAsset struct {
+	Name         string // market name used by the API, e.g. "BTC", "PURR/USDC", "@107" or "xyz:XYZ100"
+	AssetId      int    // asset id used in actions, including the spot and perp dex offsets
+	Index        int    // index of the market in its universe
+	MarketType   MarketType
+	Dex          string // HIP-3 perp dex, empty for the first perp dex and for spot
+	SzDecimals   int
+	WeiDecimals  int // spot only, of the base token
+	PxDecimals   int // maximum number of decimals of the price
+	MaxLeverage  int // perp only
+	OnlyIsolated bool
+	IsDelisted   bool
+	BaseToken    string // spot only
+	QuoteToken   string // spot only
+}
+
+// IsSpot returns whether the asset is a spot market.
+func (asset ResolvedAsset) IsSpot() bool {
+	return asset.MarketType == MarketTypeSpot
+}
+
 type OrderRequest struct {
 	Coin       string    `json:"coin"`
 	IsBuy      bool      `json:"is_buy"`
@@ -33,7 +77,7 @@ type OrderRequest struct {
 	LimitPx    float64   `json:"limit_px"`
 	OrderType  OrderType `json:"order_type"`
 	ReduceOnly bool      `json:"reduce_only"`
-	Cloid      string    `json:"cloid,omitempty"`
+	Cloid      Cloid     `json:"cloid,omitempty"`
 }
 
 type OrderType struct {
@@ -85,8 +129,11 @@ type ModifyResponse struct {
 	Status   string             `json:"status"`
 	Response OrderInnerResponse `json:"response"`
 }
+
+// ModifyOrderWire identifies the order to modify by OrderId, or by Cloid if OrderId is 0.
 type ModifyOrderWire struct {
 	OrderId int       `msgpack:"oid" json:"oid"`
+	Cloid   Cloid     `msgpack:"-" json:"-"`
 	Order   OrderWire `msgpack:"order" json:"order"`
 }
 type ModifyOrderAction struct {
@@ -94,6 +141,8 @@ type ModifyOrderAction struct {
 	Modifies []ModifyOrderWire `msgpack:"modifies" json:"modifies"`
 }
 
+// ModifyOrderRequest replaces the order OrderId with a new one that gets Cloid.
+// If OrderId is 0 the order is identified by Cloid instead and keeps it.
 type ModifyOrderRequest struct {
 	OrderId    int       `json:"oid"`
 	Coin       string    `json:"coin"`
@@ -102,7 +151,7 @@ type ModifyOrderRequest struct {
 	LimitPx    float64   `json:"limit_px"`
 	OrderType  OrderType `json:"order_type"`
 	ReduceOnly bool      `json:"reduce_only"`
-	Cloid      string    `json:"cloid,omitempty"`
+	Cloid      Cloid     `json:"cloid,omitempty"`
 }
 
 type OrderTypeWire struct {
@@ -111,9 +160,17 @@ type OrderTypeWire struct {
 }
 
 type PlaceOrderAction struct {
-	Type     string      `msgpack:"type" json:"type"`
-	Orders   []OrderWire `msgpack:"orders" json:"orders"`
-	Grouping Grouping    `msgpack:"grouping" json:"grouping"`
+	Type     string       `msgpack:"type" json:"type"`
+	Orders   []OrderWire  `msgpack:"orders" json:"orders"`
+	Grouping Grouping     `msgpack:"grouping" json:"grouping"`
+	Builder  *BuilderInfo `msgpack:"builder,omitempty" json:"builder,omitempty"`
+}
+
+// BuilderInfo is the builder that receives a fee on the orders it routes.
+// The builder must be approved by the user with ApproveBuilderFee for at least Fee.
+type BuilderInfo struct {
+	Builder string `msgpack:"b" json:"b"` // address of the builder, lowercase
+	Fee     int    `msgpack:"f" json:"f"` // fee in tenths of a basis point, e.g. 10 is 0.01%
 }
 
 type OrderResponse struct {
@@ -179,6 +236,12 @@ type CancelCloidWire struct {
 	Cloid string `msgpack:"cloid" json:"cloid"`
 }
 
+// CancelCloidRequest identifies an order to cancel by its client order id.
+type CancelCloidRequest struct {
+	Coin  string `json:"coin"`
+	Cloid Cloid  `json:"cloid"`
+}
+
 type CancelCloidOrderAction struct {
 	Type    string            `msgpack:"type" json:"type"`
 	Cancels []CancelCloidWire `msgpack:"cancels" json:"cancels"`
@@ -279,3 +342,188 @@ type UsdClassTransferAction struct {
 	SignatureChainID string  `msgpack:"signatureChainId" json:"signatureChainId"`
 	Subaccount       *string `msgpack:"subaccount,omitempty" json:"subaccount,omitempty"`
 }
+
+type ApproveAgentAction struct {
+	Type             string `msgpack:"type" json:"type"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	AgentAddress     string `msgpack:"agentAddress" json:"agentAddress"`
+	AgentName        string `msgpack:"agentName,omitempty" json:"agentName,omitempty"`
+	Nonce            uint64 `msgpack:"nonce" json:"nonce"`
+}
+
+type TwapWire struct {
+	Asset      int    `msgpack:"a" json:"a"`
+	IsBuy      bool   `msgpack:"b" json:"b"`
+	Size       string `msgpack:"s" json:"s"`
+	ReduceOnly bool   `msgpack:"r" json:"r"`
+	Minutes    int    `msgpack:"m" json:"m"`
+	Randomize  bool   `msgpack:"t" json:"t"`
+}
+
+type TwapOrderAction struct {
+	Type string   `msgpack:"type" json:"type"`
+	Twap TwapWire `msgpack:"twap" json:"twap"`
+}
+
+type TwapCancelAction struct {
+	Type   string `msgpack:"type" json:"type"`
+	Asset  int    `msgpack:"a" json:"a"`
+	TwapId int64  `msgpack:"t" json:"t"`
+}
+
+type TwapOrderResponse struct {
+	Status   string `json:"status"`
+	Response struct {
+		Type string `json:"type"`
+		Data struct {
+			Status TwapOrderStatus `json:"status"`
+		} `json:"data"`
+	} `json:"response"`
+}
+
+// TwapId returns the id of the new TWAP, or an OrderError if it was rejected.
+func (response *TwapOrderResponse) TwapId() (int64, error) {
+	status := response.Response.Data.Status
+	if status.Error != "" {
+		return 0, &OrderError{Index: 0, Message: status.Error}
+	}
+	if status.Running == nil {
+		return 0, &OrderError{Index: 0, Message: "missing twap status"}
+	}
+	return status.Running.TwapId, nil
+}
+
+// TwapOrderStatus is either running with the id of the new TWAP or an error.
+type TwapOrderStatus struct {
+	Running *TwapRunningStatus `json:"running,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+type TwapRunningStatus struct {
+	TwapId int64 `json:"twapId"`
+}
+
+type TwapCancelResponse struct {
+	Status   string `json:"status"`
+	Response struct {
+		Type string `json:"type"`
+		Data struct {
+			Status TwapCancelStatus `json:"status"`
+		} `json:"data"`
+	} `json:"response"`
+}
+
+// Err returns an OrderError if the TWAP could not be canceled, nil otherwise.
+func (response *TwapCancelResponse) Err() error {
+	status := response.Response.Data.Status
+	if status.Error != "" {
+		return &OrderError{Index: 0, Message: status.Error}
+	}
+	return nil
+}
+
+// TwapCancelStatus is either "success" or an error.
+type TwapCancelStatus struct {
+	Success bool
+	Error   string
+}
+
+// UnmarshalJSON implements custom unmarshaling for TwapCancelStatus:
+// the status is the string "success" or an object {"error": "..."}.
+func (status *TwapCancelStatus) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		status.Success = text == "success"
+		return nil
+	}
+	var result struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	status.Error = result.Error
+	return nil
+}
+
+type ScheduleCancelAction struct {
+	Type string `msgpack:"type" json:"type"`
+	Time *int64 `msgpack:"time,omitempty" json:"time,omitempty"`
+}
+
+type UpdateIsolatedMarginAction struct {
+	Type  string `msgpack:"type" json:"type"`
+	Asset int    `msgpack:"asset" json:"asset"`
+	IsBuy bool   `msgpack:"isBuy" json:"isBuy"`
+	Ntli  int64  `msgpack:"ntli" json:"ntli"`
+}
+
+type UsdSendAction struct {
+	Type             string `msgpack:"type" json:"type"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	Destination      string `msgpack:"destination" json:"destination"`
+	Amount           string `msgpack:"amount" json:"amount"`
+	Time             uint64 `msgpack:"time" json:"time"`
+}
+
+type SpotSendAction struct {
+	Type             string `msgpack:"type" json:"type"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	Destination      string `msgpack:"destination" json:"destination"`
+	Token            string `msgpack:"token" json:"token"`
+	Amount           string `msgpack:"amount" json:"amount"`
+	Time             uint64 `msgpack:"time" json:"time"`
+}
+
+type CreateSubAccountAction struct {
+	Type string `msgpack:"type" json:"type"`
+	Name string `msgpack:"name" json:"name"`
+}
+
+type CreateSubAccountResponse struct {
+	Status   string `json:"status"`
+	Response struct {
+		Type string `json:"type"`
+		Data string `json:"data"` // address of the new subaccount
+	} `json:"response"`
+}
+
+type SubAccountModifyAction struct {
+	Type           string `msgpack:"type" json:"type"`
+	SubAccountUser string `msgpack:"subAccountUser" json:"subAccountUser"`
+	Name           string `msgpack:"name" json:"name"`
+}
+
+type SubAccountTransferAction struct {
+	Type           string `msgpack:"type" json:"type"`
+	SubAccountUser string `msgpack:"subAccountUser" json:"subAccountUser"`
+	IsDeposit      bool   `msgpack:"isDeposit" json:"isDeposit"`
+	Usd            int64  `msgpack:"usd" json:"usd"`
+}
+
+type SubAccountSpotTransferAction struct {
+	Type           string `msgpack:"type" json:"type"`
+	SubAccountUser string `msgpack:"subAccountUser" json:"subAccountUser"`
+	IsDeposit      bool   `msgpack:"isDeposit" json:"isDeposit"`
+	Token          string `msgpack:"token" json:"token"`
+	Amount         string `msgpack:"amount" json:"amount"`
+}
+
+type VaultTransferAction struct {
+	Type         string `msgpack:"type" json:"type"`
+	VaultAddress string `msgpack:"vaultAddress" json:"vaultAddress"`
+	IsDeposit    bool   `msgpack:"isDeposit" json:"isDeposit"`
+	Usd          int64  `msgpack:"usd" json:"usd"`
+}
+
+type ApproveBuilderFeeAction struct {
+	Type             string `msgpack:"type" json:"type"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	MaxFeeRate       string `msgpack:"maxFeeRate" json:"maxFeeRate"`
+	Builder          string `msgpack:"builder" json:"builder"`
+	Nonce            uint64 `msgpack:"nonce" json:"nonce"`
+}
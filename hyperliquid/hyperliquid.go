@@ -1,5 +1,7 @@
 package hyperliquid
 
+import "context"
+
 type IHyperliquid interface {
 	IExchangeAPI
 	IInfoAPI
@@ -12,12 +14,20 @@ type Hyperliquid struct {
 
 // HyperliquidClientConfig is a configuration struct for Hyperliquid API.
 // PrivateKey can be empty if you only need to use the public endpoints.
+// Signer is optional, when set it signs exchange actions instead of PrivateKey (e.g. a RemoteSigner).
 // AccountAddress is the default account address for the API that can be changed with SetAccountAddress().
 // AccountAddress may be different from the address build from the private key due to Hyperliquid's account system.
+// VaultAddress is optional, when set orders and cancels are placed on behalf of this vault or subaccount.
+// Builder is optional, when set it receives its fee on every order.
+// Options are passed to the underlying clients, e.g. to use a custom HTTP client or base URL.
 type HyperliquidClientConfig struct {
 	IsMainnet      bool
 	PrivateKey     string
+	Signer         Signer
 	AccountAddress string
+	VaultAddress   string
+	Builder        *BuilderInfo
+	Options        []ClientOption
 }
 
 func NewHyperliquid(config *HyperliquidClientConfig) *Hyperliquid {
@@ -31,17 +41,40 @@ func NewHyperliquid(config *HyperliquidClientConfig) *Hyperliquid {
 	} else {
 		defaultConfig = config
 	}
-	exchangeAPI := NewExchangeAPI(defaultConfig.IsMainnet)
+	exchangeAPI := NewExchangeAPI(defaultConfig.IsMainnet, defaultConfig.Options...)
 	exchangeAPI.SetPrivateKey(defaultConfig.PrivateKey)
+	if defaultConfig.Signer != nil {
+		exchangeAPI.SetSigner(defaultConfig.Signer)
+	}
 	exchangeAPI.SetAccountAddress(defaultConfig.AccountAddress)
-	infoAPI := NewInfoAPI(defaultConfig.IsMainnet)
+	exchangeAPI.SetVaultAddress(defaultConfig.VaultAddress)
+	exchangeAPI.SetBuilder(defaultConfig.Builder)
+	infoAPI := NewInfoAPI(defaultConfig.IsMainnet, defaultConfig.Options...)
 	infoAPI.SetAccountAddress(defaultConfig.AccountAddress)
+	// share the metadata so it is loaded only once
+	infoAPI.SetAssetResolver(exchangeAPI.AssetResolver())
 	return &Hyperliquid{
 		ExchangeAPI: *exchangeAPI,
 		InfoAPI:     *infoAPI,
 	}
 }
 
+// Init loads the market metadata. It is optional as the metadata is loaded on first use,
+// but it allows to detect an unreachable API on startup.
+func (h *Hyperliquid) Init(ctx context.Context) error {
+	return h.ExchangeAPI.Init(ctx)
+}
+
+// LoadMetaSnapshot seeds the market metadata from a saved snapshot, see InfoAPI.LoadMetaSnapshot.
+func (h *Hyperliquid) LoadMetaSnapshot(snapshot MetaSnapshot) {
+	h.ExchangeAPI.LoadMetaSnapshot(snapshot)
+}
+
+// MetaSnapshot returns a copy of the cached market metadata.
+func (h *Hyperliquid) MetaSnapshot() MetaSnapshot {
+	return h.ExchangeAPI.MetaSnapshot()
+}
+
 func (h *Hyperliquid) SetDebugActive() {
 	h.ExchangeAPI.SetDebugActive()
 	h.InfoAPI.SetDebugActive()
@@ -55,6 +88,14 @@ func (h *Hyperliquid) SetPrivateKey(privateKey string) error {
 	return nil
 }
 
+func (h *Hyperliquid) SetKeyManager(keyManager *PKeyManager) {
+	h.ExchangeAPI.SetKeyManager(keyManager)
+}
+
+func (h *Hyperliquid) SetSigner(signer Signer) {
+	h.ExchangeAPI.SetSigner(signer)
+}
+
 func (h *Hyperliquid) SetAccountAddress(accountAddress string) {
 	h.ExchangeAPI.SetAccountAddress(accountAddress)
 	h.InfoAPI.SetAccountAddress(accountAddress)
@@ -69,7 +110,7 @@ func (h *Hyperliquid) IsMainnet() bool {
 }
 
 // GetFuturesMarketPrecision returns a map from perpetual futures symbol to its size decimals (szDecimals).
-// This uses the cached metadata that was already fetched during initialization, avoiding additional API calls.
+// This uses the cached metadata loaded by Init(), LoadMetaSnapshot() or a previous request, avoiding additional API calls.
 // Use this to initialize precision for each market when setting up your trading client.
 //
 // The actual minimum lot size step can be calculated as 1/10^szDecimals.
@@ -1,6 +1,7 @@
 package hyperliquid
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"maps"
@@ -14,51 +15,86 @@ type IInfoAPI interface {
 
 	// INFO API ENDPOINTS
 	GetAllMids() (*map[string]string, error)
+	GetAllMidsWithContext(ctx context.Context) (*map[string]string, error)
 	GetOpenOrders(address string) (*[]Order, error)
+	GetOpenOrdersWithContext(ctx context.Context, address string) (*[]Order, error)
 	GetAccountOpenOrders() (*[]Order, error)
+	GetAccountOpenOrdersWithContext(ctx context.Context) (*[]Order, error)
 	GetUserFills(address string) (*[]OrderFill, error)
+	GetUserFillsWithContext(ctx context.Context, address string) (*[]OrderFill, error)
 	GetAccountFills() (*[]OrderFill, error)
-	GetUserRateLimits(address string) (*float64, error)
+	GetAccountFillsWithContext(ctx context.Context) (*[]OrderFill, error)
+	GetUserRateLimits(address string) (*RatesLimits, error)
+	GetUserRateLimitsWithContext(ctx context.Context, address string) (*RatesLimits, error)
 	GetL2BookSnapshot(coin string) (*L2BookSnapshot, error)
-	GetCandleSnapshot(coin string, interval string, startTime int64, endTime int64) (*CandleSnapshot, error)
+	GetL2BookSnapshotWithContext(ctx context.Context, coin string) (*L2BookSnapshot, error)
+	GetCandleSnapshot(coin string, interval string, startTime int64, endTime int64) (*[]CandleSnapshot, error)
+	GetCandleSnapshotWithContext(ctx context.Context, coin string, interval string, startTime int64, endTime int64) (*[]CandleSnapshot, error)
 
 	// PERPETUALS INFO API ENDPOINTS
 	GetMeta() (*Meta, error)
+	GetMetaWithContext(ctx context.Context) (*Meta, error)
+	GetPerpDexMeta(dex string) (*Meta, error)
+	GetPerpDexMetaWithContext(ctx context.Context, dex string) (*Meta, error)
+	GetPerpDexs() (*[]PerpDex, error)
+	GetPerpDexsWithContext(ctx context.Context) (*[]PerpDex, error)
 	GetUserState(address string) (*UserState, error)
+	GetUserStateWithContext(ctx context.Context, address string) (*UserState, error)
 	GetAccountState() (*UserState, error)
+	GetAccountStateWithContext(ctx context.Context) (*UserState, error)
 	GetFundingUpdates(address string, startTime int64, endTime int64) (*[]FundingUpdate, error)
+	GetFundingUpdatesWithContext(ctx context.Context, address string, startTime int64, endTime int64) (*[]FundingUpdate, error)
 	GetAccountFundingUpdates(startTime int64, endTime int64) (*[]FundingUpdate, error)
+	GetAccountFundingUpdatesWithContext(ctx context.Context, startTime int64, endTime int64) (*[]FundingUpdate, error)
 	GetNonFundingUpdates(address string, startTime int64, endTime int64) (*[]NonFundingUpdate, error)
+	GetNonFundingUpdatesWithContext(ctx context.Context, address string, startTime int64, endTime int64) (*[]NonFundingUpdate, error)
 	GetAccountNonFundingUpdates(startTime int64, endTime int64) (*[]NonFundingUpdate, error)
-	GetHistoricalFundingRates() (*[]HistoricalFundingRate, error)
+	GetAccountNonFundingUpdatesWithContext(ctx context.Context, startTime int64, endTime int64) (*[]NonFundingUpdate, error)
+	GetHistoricalFundingRates(coin string, startTime int64, endTime int64) (*[]HistoricalFundingRate, error)
+	GetHistoricalFundingRatesWithContext(ctx context.Context, coin string, startTime int64, endTime int64) (*[]HistoricalFundingRate, error)
 
 	// Additional helper functions
 	GetMartketPx(coin string) (float64, error)
+	GetMartketPxWithContext(ctx context.Context, coin string) (float64, error)
 	BuildMetaMap() (map[string]AssetInfo, error)
+	BuildMetaMapWithContext(ctx context.Context) (map[string]AssetInfo, error)
 	GetWithdrawals(address string) (*[]Withdrawal, error)
+	GetWithdrawalsWithContext(ctx context.Context, address string) (*[]Withdrawal, error)
 	GetAccountWithdrawals() (*[]Withdrawal, error)
+	GetAccountWithdrawalsWithContext(ctx context.Context) (*[]Withdrawal, error)
+	GetExtraAgents(address string) (*[]ExtraAgent, error)
+	GetExtraAgentsWithContext(ctx context.Context, address string) (*[]ExtraAgent, error)
+	GetAccountExtraAgents() (*[]ExtraAgent, error)
+	GetAccountExtraAgentsWithContext(ctx context.Context) (*[]ExtraAgent, error)
+	GetSubAccounts(address string) (*[]SubAccount, error)
+	GetSubAccountsWithContext(ctx context.Context, address string) (*[]SubAccount, error)
+	GetAccountSubAccounts() (*[]SubAccount, error)
+	GetAccountSubAccountsWithContext(ctx context.Context) (*[]SubAccount, error)
+	GetVaultDetails(vaultAddress string, user string) (*VaultDetails, error)
+	GetVaultDetailsWithContext(ctx context.Context, vaultAddress string, user string) (*VaultDetails, error)
+	GetUserVaultEquities(address string) (*[]UserVaultEquity, error)
+	GetUserVaultEquitiesWithContext(ctx context.Context, address string) (*[]UserVaultEquity, error)
+	GetMaxBuilderFee(user string, builder string) (*int, error)
+	GetMaxBuilderFeeWithContext(ctx context.Context, user string, builder string) (*int, error)
 }
 
 type InfoAPI struct {
 	Client
 	baseEndpoint string
-	spotMeta     map[string]AssetInfo
+	assets       *AssetResolver
 }
 
 // NewInfoAPI returns a new instance of the InfoAPI struct.
 // It sets the base endpoint to "/info" and the client to the NewClient function.
 // The isMainnet parameter is used to set the network type.
-func NewInfoAPI(isMainnet bool) *InfoAPI {
+// The opts are passed to NewClient.
+// No request is made here, the metadata is loaded on first use or by Init().
+func NewInfoAPI(isMainnet bool, opts ...ClientOption) *InfoAPI {
 	api := InfoAPI{
 		baseEndpoint: "/info",
-		Client:       *NewClient(isMainnet),
+		Client:       *NewClient(isMainnet, opts...),
 	}
-	spotMeta, err := api.BuildSpotMetaMap()
-	if err != nil {
-		api.SetDebugActive()
-		api.debug("Error building meta map: %s", err)
-	}
-	api.spotMeta = spotMeta
+	api.assets = NewAssetResolver(&api)
 	return &api
 }
 
@@ -69,19 +105,29 @@ func (api *InfoAPI) Endpoint() string {
 // Retrieve mids for all actively traded coins
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-mids-for-all-actively-traded-coins
 func (api *InfoAPI) GetAllMids() (*map[string]string, error) {
+	return api.GetAllMidsWithContext(context.Background())
+}
+
+// GetAllMidsWithContext is the same as GetAllMids but the request is bound to ctx.
+func (api *InfoAPI) GetAllMidsWithContext(ctx context.Context) (*map[string]string, error) {
 	request := InfoRequest{
 		Typez: "allMids",
 	}
-	return MakeUniversalRequest[map[string]string](api, request)
+	return MakeUniversalRequestWithContext[map[string]string](ctx, api, request)
 }
 
 // Retrieve spot meta and asset contexts
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/spot#retrieve-spot-asset-contexts
 func (api *InfoAPI) GetAllSpotPrices() (*map[string]string, error) {
+	return api.GetAllSpotPricesWithContext(context.Background())
+}
+
+// GetAllSpotPricesWithContext is the same as GetAllSpotPrices but the request is bound to ctx.
+func (api *InfoAPI) GetAllSpotPricesWithContext(ctx context.Context) (*map[string]string, error) {
 	request := InfoRequest{
 		Typez: "spotMetaAndAssetCtxs",
 	}
-	response, err := MakeUniversalRequest[SpotMetaAndAssetCtxsResponse](api, request)
+	response, err := MakeUniversalRequestWithContext[SpotMetaAndAssetCtxsResponse](ctx, api, request)
 	if err != nil {
 		return nil, err
 	}
@@ -113,67 +159,107 @@ func (api *InfoAPI) GetAllSpotPrices() (*map[string]string, error) {
 // Retrieve a user's open orders
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-open-orders
 func (api *InfoAPI) GetOpenOrders(address string) (*[]Order, error) {
+	return api.GetOpenOrdersWithContext(context.Background(), address)
+}
+
+// GetOpenOrdersWithContext is the same as GetOpenOrders but the request is bound to ctx.
+func (api *InfoAPI) GetOpenOrdersWithContext(ctx context.Context, address string) (*[]Order, error) {
 	request := InfoRequest{
 		User:  address,
 		Typez: "openOrders",
 	}
-	return MakeUniversalRequest[[]Order](api, request)
+	return MakeUniversalRequestWithContext[[]Order](ctx, api, request)
 }
 
 // Retrieve a account's order history
 // The same as GetOpenOrders but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountOpenOrders() (*[]Order, error) {
-	return api.GetOpenOrders(api.AccountAddress())
+	return api.GetAccountOpenOrdersWithContext(context.Background())
+}
+
+// GetAccountOpenOrdersWithContext is the same as GetAccountOpenOrders but the request is bound to ctx.
+func (api *InfoAPI) GetAccountOpenOrdersWithContext(ctx context.Context) (*[]Order, error) {
+	return api.GetOpenOrdersWithContext(ctx, api.AccountAddress())
 }
 
 // Retrieve a user's fills
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-fills
 func (api *InfoAPI) GetUserFills(address string) (*[]OrderFill, error) {
+	return api.GetUserFillsWithContext(context.Background(), address)
+}
+
+// GetUserFillsWithContext is the same as GetUserFills but the request is bound to ctx.
+func (api *InfoAPI) GetUserFillsWithContext(ctx context.Context, address string) (*[]OrderFill, error) {
 	request := InfoRequest{
 		User:  address,
 		Typez: "userFills",
 	}
-	return MakeUniversalRequest[[]OrderFill](api, request)
+	return MakeUniversalRequestWithContext[[]OrderFill](ctx, api, request)
 }
 
 // Retrieve a account's fill history
 // The same as GetUserFills but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountFills() (*[]OrderFill, error) {
-	return api.GetUserFills(api.AccountAddress())
+	return api.GetAccountFillsWithContext(context.Background())
+}
+
+// GetAccountFillsWithContext is the same as GetAccountFills but the request is bound to ctx.
+func (api *InfoAPI) GetAccountFillsWithContext(ctx context.Context) (*[]OrderFill, error) {
+	return api.GetUserFillsWithContext(ctx, api.AccountAddress())
 }
 
 // Query user rate limits
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-user-rate-limits
 func (api *InfoAPI) GetUserRateLimits(address string) (*RatesLimits, error) {
+	return api.GetUserRateLimitsWithContext(context.Background(), address)
+}
+
+// GetUserRateLimitsWithContext is the same as GetUserRateLimits but the request is bound to ctx.
+func (api *InfoAPI) GetUserRateLimitsWithContext(ctx context.Context, address string) (*RatesLimits, error) {
 	request := InfoRequest{
 		User:  address,
 		Typez: "userRateLimit",
 	}
-	return MakeUniversalRequest[RatesLimits](api, request)
+	return MakeUniversalRequestWithContext[RatesLimits](ctx, api, request)
 }
 
 // Query account rate limits
 // The same as GetUserRateLimits but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountRateLimits() (*RatesLimits, error) {
-	return api.GetUserRateLimits(api.AccountAddress())
+	return api.GetAccountRateLimitsWithContext(context.Background())
+}
+
+// GetAccountRateLimitsWithContext is the same as GetAccountRateLimits but the request is bound to ctx.
+func (api *InfoAPI) GetAccountRateLimitsWithContext(ctx context.Context) (*RatesLimits, error) {
+	return api.GetUserRateLimitsWithContext(ctx, api.AccountAddress())
 }
 
 // L2 Book snapshot
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#l2-book-snapshot
 func (api *InfoAPI) GetL2BookSnapshot(coin string) (*L2BookSnapshot, error) {
+	return api.GetL2BookSnapshotWithContext(context.Background(), coin)
+}
+
+// GetL2BookSnapshotWithContext is the same as GetL2BookSnapshot but the request is bound to ctx.
+func (api *InfoAPI) GetL2BookSnapshotWithContext(ctx context.Context, coin string) (*L2BookSnapshot, error) {
 	request := InfoRequest{
 		Typez: "l2Book",
 		Coin:  coin,
 	}
-	return MakeUniversalRequest[L2BookSnapshot](api, request)
+	return MakeUniversalRequestWithContext[L2BookSnapshot](ctx, api, request)
 }
 
 // Candle snapshot (Only the most recent 5000 candles are available)
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#candle-snapshot
 func (api *InfoAPI) GetCandleSnapshot(coin string, interval string, startTime int64, endTime int64) (*[]CandleSnapshot, error) {
+	return api.GetCandleSnapshotWithContext(context.Background(), coin, interval, startTime, endTime)
+}
+
+// GetCandleSnapshotWithContext is the same as GetCandleSnapshot but the request is bound to ctx.
+func (api *InfoAPI) GetCandleSnapshotWithContext(ctx context.Context, coin string, interval string, startTime int64, endTime int64) (*[]CandleSnapshot, error) {
 	request := CandleSnapshotRequest{
 		Typez: "candleSnapshot",
 		Req: CandleSnapshotSubRequest{
@@ -183,108 +269,296 @@ func (api *InfoAPI) GetCandleSnapshot(coin string, interval string, startTime in
 			EndTime:   endTime,
 		},
 	}
-	return MakeUniversalRequest[[]CandleSnapshot](api, request)
+	return MakeUniversalRequestWithContext[[]CandleSnapshot](ctx, api, request)
 }
 
 // Retrieve perpetuals metadata
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-perpetuals-metadata
 func (api *InfoAPI) GetMeta() (*Meta, error) {
+	return api.GetMetaWithContext(context.Background())
+}
+
+// GetMetaWithContext is the same as GetMeta but the request is bound to ctx.
+func (api *InfoAPI) GetMetaWithContext(ctx context.Context) (*Meta, error) {
 	request := InfoRequest{
 		Typez: "meta",
 	}
-	return MakeUniversalRequest[Meta](api, request)
+	return MakeUniversalRequestWithContext[Meta](ctx, api, request)
+}
+
+// Retrieve the perpetuals metadata of a HIP-3 perp dex, the coins are prefixed with the dex name (e.g. "xyz:XYZ100").
+// An empty dex is the first perp dex, the same as GetMeta.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-perpetuals-metadata-universe-and-margin-tables
+func (api *InfoAPI) GetPerpDexMeta(dex string) (*Meta, error) {
+	return api.GetPerpDexMetaWithContext(context.Background(), dex)
+}
+
+// GetPerpDexMetaWithContext is the same as GetPerpDexMeta but the request is bound to ctx.
+func (api *InfoAPI) GetPerpDexMetaWithContext(ctx context.Context, dex string) (*Meta, error) {
+	request := InfoRequest{
+		Typez: "meta",
+		Dex:   dex,
+	}
+	return MakeUniversalRequestWithContext[Meta](ctx, api, request)
+}
+
+// Retrieve all perp dexes. The position of a dex in the list is its index,
+// used to compute the asset ids of its coins. The first one is the default perp dex.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-all-perpetual-dexs
+func (api *InfoAPI) GetPerpDexs() (*[]PerpDex, error) {
+	return api.GetPerpDexsWithContext(context.Background())
+}
+
+// GetPerpDexsWithContext is the same as GetPerpDexs but the request is bound to ctx.
+func (api *InfoAPI) GetPerpDexsWithContext(ctx context.Context) (*[]PerpDex, error) {
+	request := InfoRequest{
+		Typez: "perpDexs",
+	}
+	return MakeUniversalRequestWithContext[[]PerpDex](ctx, api, request)
 }
 
 // Retrieve spot metadata
 func (api *InfoAPI) GetSpotMeta() (*SpotMeta, error) {
+	return api.GetSpotMetaWithContext(context.Background())
+}
+
+// GetSpotMetaWithContext is the same as GetSpotMeta but the request is bound to ctx.
+func (api *InfoAPI) GetSpotMetaWithContext(ctx context.Context) (*SpotMeta, error) {
 	request := InfoRequest{
 		Typez: "spotMeta",
 	}
-	return MakeUniversalRequest[SpotMeta](api, request)
+	return MakeUniversalRequestWithContext[SpotMeta](ctx, api, request)
 }
 
 // Retrieve user's perpetuals account summary
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-users-perpetuals-account-summary
 func (api *InfoAPI) GetUserState(address string) (*UserState, error) {
+	return api.GetUserStateWithContext(context.Background(), address)
+}
+
+// GetUserStateWithContext is the same as GetUserState but the request is bound to ctx.
+func (api *InfoAPI) GetUserStateWithContext(ctx context.Context, address string) (*UserState, error) {
 	request := UserStateRequest{
 		User:  address,
 		Typez: "clearinghouseState",
 	}
-	return MakeUniversalRequest[UserState](api, request)
+	return MakeUniversalRequestWithContext[UserState](ctx, api, request)
 }
 
 // Retrieve account's perpetuals account summary
 // The same as GetUserState but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountState() (*UserState, error) {
-	return api.GetUserState(api.AccountAddress())
+	return api.GetAccountStateWithContext(context.Background())
+}
+
+// GetAccountStateWithContext is the same as GetAccountState but the request is bound to ctx.
+func (api *InfoAPI) GetAccountStateWithContext(ctx context.Context) (*UserState, error) {
+	return api.GetUserStateWithContext(ctx, api.AccountAddress())
 }
 
 // Retrieve user's spot account summary
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/spot#retrieve-a-users-token-balances
 func (api *InfoAPI) GetUserStateSpot(address string) (*UserStateSpot, error) {
+	return api.GetUserStateSpotWithContext(context.Background(), address)
+}
+
+// GetUserStateSpotWithContext is the same as GetUserStateSpot but the request is bound to ctx.
+func (api *InfoAPI) GetUserStateSpotWithContext(ctx context.Context, address string) (*UserStateSpot, error) {
 	request := UserStateRequest{
 		User:  address,
 		Typez: "spotClearinghouseState",
 	}
-	return MakeUniversalRequest[UserStateSpot](api, request)
+	return MakeUniversalRequestWithContext[UserStateSpot](ctx, api, request)
 }
 
 // Retrieve account's spot account summary
 // The same as GetUserStateSpot but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountStateSpot() (*UserStateSpot, error) {
-	return api.GetUserStateSpot(api.AccountAddress())
+	return api.GetAccountStateSpotWithContext(context.Background())
+}
+
+// GetAccountStateSpotWithContext is the same as GetAccountStateSpot but the request is bound to ctx.
+func (api *InfoAPI) GetAccountStateSpotWithContext(ctx context.Context) (*UserStateSpot, error) {
+	return api.GetUserStateSpotWithContext(ctx, api.AccountAddress())
 }
 
 // Retrieve a user's funding history
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-a-users-funding-history-or-non-funding-ledger-updates
 func (api *InfoAPI) GetFundingUpdates(address string, startTime int64, endTime int64) (*[]FundingUpdate, error) {
+	return api.GetFundingUpdatesWithContext(context.Background(), address, startTime, endTime)
+}
+
+// GetFundingUpdatesWithContext is the same as GetFundingUpdates but the request is bound to ctx.
+func (api *InfoAPI) GetFundingUpdatesWithContext(ctx context.Context, address string, startTime int64, endTime int64) (*[]FundingUpdate, error) {
 	request := InfoRequest{
 		User:      address,
 		Typez:     "userFunding",
 		StartTime: startTime,
 		EndTime:   endTime,
 	}
-	return MakeUniversalRequest[[]FundingUpdate](api, request)
+	return MakeUniversalRequestWithContext[[]FundingUpdate](ctx, api, request)
 }
 
 // Retrieve account's funding history
 // The same as GetFundingUpdates but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountFundingUpdates(startTime int64, endTime int64) (*[]FundingUpdate, error) {
-	return api.GetFundingUpdates(api.AccountAddress(), startTime, endTime)
+	return api.GetAccountFundingUpdatesWithContext(context.Background(), startTime, endTime)
+}
+
+// GetAccountFundingUpdatesWithContext is the same as GetAccountFundingUpdates but the request is bound to ctx.
+func (api *InfoAPI) GetAccountFundingUpdatesWithContext(ctx context.Context, startTime int64, endTime int64) (*[]FundingUpdate, error) {
+	return api.GetFundingUpdatesWithContext(ctx, api.AccountAddress(), startTime, endTime)
 }
 
 // Retrieve a user's funding history or non-funding ledger updates
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-a-users-funding-history-or-non-funding-ledger-updates
 func (api *InfoAPI) GetNonFundingUpdates(address string, startTime int64, endTime int64) (*[]NonFundingUpdate, error) {
+	return api.GetNonFundingUpdatesWithContext(context.Background(), address, startTime, endTime)
+}
+
+// GetNonFundingUpdatesWithContext is the same as GetNonFundingUpdates but the request is bound to ctx.
+func (api *InfoAPI) GetNonFundingUpdatesWithContext(ctx context.Context, address string, startTime int64, endTime int64) (*[]NonFundingUpdate, error) {
 	request := InfoRequest{
 		User:      address,
 		Typez:     "userNonFundingLedgerUpdates",
 		StartTime: startTime,
 		EndTime:   endTime,
 	}
-	return MakeUniversalRequest[[]NonFundingUpdate](api, request)
+	return MakeUniversalRequestWithContext[[]NonFundingUpdate](ctx, api, request)
 }
 
 // Retrieve account's funding history or non-funding ledger updates
 // The same as GetNonFundingUpdates but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountNonFundingUpdates(startTime int64, endTime int64) (*[]NonFundingUpdate, error) {
-	return api.GetNonFundingUpdates(api.AccountAddress(), startTime, endTime)
+	return api.GetAccountNonFundingUpdatesWithContext(context.Background(), startTime, endTime)
+}
+
+// GetAccountNonFundingUpdatesWithContext is the same as GetAccountNonFundingUpdates but the request is bound to ctx.
+func (api *InfoAPI) GetAccountNonFundingUpdatesWithContext(ctx context.Context, startTime int64, endTime int64) (*[]NonFundingUpdate, error) {
+	return api.GetNonFundingUpdatesWithContext(ctx, api.AccountAddress(), startTime, endTime)
 }
 
 // Retrieve historical funding rates
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-historical-funding-rates
 func (api *InfoAPI) GetHistoricalFundingRates(coin string, startTime int64, endTime int64) (*[]HistoricalFundingRate, error) {
+	return api.GetHistoricalFundingRatesWithContext(context.Background(), coin, startTime, endTime)
+}
+
+// GetHistoricalFundingRatesWithContext is the same as GetHistoricalFundingRates but the request is bound to ctx.
+func (api *InfoAPI) GetHistoricalFundingRatesWithContext(ctx context.Context, coin string, startTime int64, endTime int64) (*[]HistoricalFundingRate, error) {
 	request := InfoRequest{
 		Typez:     "fundingHistory",
 		Coin:      coin,
 		StartTime: startTime,
 		EndTime:   endTime,
 	}
-	return MakeUniversalRequest[[]HistoricalFundingRate](api, request)
+	return MakeUniversalRequestWithContext[[]HistoricalFundingRate](ctx, api, request)
+}
+
+// Retrieve the API wallets (agents) approved by a user
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint
+func (api *InfoAPI) GetExtraAgents(address string) (*[]ExtraAgent, error) {
+	return api.GetExtraAgentsWithContext(context.Background(), address)
+}
+
+// GetExtraAgentsWithContext is the same as GetExtraAgents but the request is bound to ctx.
+func (api *InfoAPI) GetExtraAgentsWithContext(ctx context.Context, address string) (*[]ExtraAgent, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "extraAgents",
+	}
+	return MakeUniversalRequestWithContext[[]ExtraAgent](ctx, api, request)
+}
+
+// Retrieve the API wallets (agents) approved by the account
+// The same as GetExtraAgents but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountExtraAgents() (*[]ExtraAgent, error) {
+	return api.GetAccountExtraAgentsWithContext(context.Background())
+}
+
+// GetAccountExtraAgentsWithContext is the same as GetAccountExtraAgents but the request is bound to ctx.
+func (api *InfoAPI) GetAccountExtraAgentsWithContext(ctx context.Context) (*[]ExtraAgent, error) {
+	return api.GetExtraAgentsWithContext(ctx, api.AccountAddress())
+}
+
+// Retrieve the subaccounts of a master account with their perp and spot states
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-subaccounts
+func (api *InfoAPI) GetSubAccounts(address string) (*[]SubAccount, error) {
+	return api.GetSubAccountsWithContext(context.Background(), address)
+}
+
+// GetSubAccountsWithContext is the same as GetSubAccounts but the request is bound to ctx.
+func (api *InfoAPI) GetSubAccountsWithContext(ctx context.Context, address string) (*[]SubAccount, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "subAccounts",
+	}
+	return MakeUniversalRequestWithContext[[]SubAccount](ctx, api, request)
+}
+
+// Retrieve the subaccounts of the account
+// The same as GetSubAccounts but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountSubAccounts() (*[]SubAccount, error) {
+	return api.GetAccountSubAccountsWithContext(context.Background())
+}
+
+// GetAccountSubAccountsWithContext is the same as GetAccountSubAccounts but the request is bound to ctx.
+func (api *InfoAPI) GetAccountSubAccountsWithContext(ctx context.Context) (*[]SubAccount, error) {
+	return api.GetSubAccountsWithContext(ctx, api.AccountAddress())
+}
+
+// Retrieve the details of a vault: portfolio history, followers, APR...
+// user is optional, when set FollowerState is the state of the user in the vault
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-details-for-a-vault
+func (api *InfoAPI) GetVaultDetails(vaultAddress string, user string) (*VaultDetails, error) {
+	return api.GetVaultDetailsWithContext(context.Background(), vaultAddress, user)
+}
+
+// GetVaultDetailsWithContext is the same as GetVaultDetails but the request is bound to ctx.
+func (api *InfoAPI) GetVaultDetailsWithContext(ctx context.Context, vaultAddress string, user string) (*VaultDetails, error) {
+	request := VaultDetailsRequest{
+		Typez:        "vaultDetails",
+		VaultAddress: vaultAddress,
+		User:         user,
+	}
+	return MakeUniversalRequestWithContext[VaultDetails](ctx, api, request)
+}
+
+// Retrieve the equity of a user in the vaults it deposited to
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-vault-deposits
+func (api *InfoAPI) GetUserVaultEquities(address string) (*[]UserVaultEquity, error) {
+	return api.GetUserVaultEquitiesWithContext(context.Background(), address)
+}
+
+// GetUserVaultEquitiesWithContext is the same as GetUserVaultEquities but the request is bound to ctx.
+func (api *InfoAPI) GetUserVaultEquitiesWithContext(ctx context.Context, address string) (*[]UserVaultEquity, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "userVaultEquities",
+	}
+	return MakeUniversalRequestWithContext[[]UserVaultEquity](ctx, api, request)
+}
+
+// Retrieve the maximum builder fee approved by a user for a builder, in tenths of a basis point
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#check-builder-fee-approval
+func (api *InfoAPI) GetMaxBuilderFee(user string, builder string) (*int, error) {
+	return api.GetMaxBuilderFeeWithContext(context.Background(), user, builder)
+}
+
+// GetMaxBuilderFeeWithContext is the same as GetMaxBuilderFee but the request is bound to ctx.
+func (api *InfoAPI) GetMaxBuilderFeeWithContext(ctx context.Context, user string, builder string) (*int, error) {
+	request := MaxBuilderFeeRequest{
+		Typez:   "maxBuilderFee",
+		User:    user,
+		Builder: builder,
+	}
+	return MakeUniversalRequestWithContext[int](ctx, api, request)
 }
 
 // Helper function to get the market price of a given coin
@@ -294,7 +568,12 @@ func (api *InfoAPI) GetHistoricalFundingRates(coin string, startTime int64, endT
 //
 //	api.GetMartketPx("BTC")
 func (api *InfoAPI) GetMartketPx(coin string) (float64, error) {
-	allMids, err := api.GetAllMids()
+	return api.GetMartketPxWithContext(context.Background(), coin)
+}
+
+// GetMartketPxWithContext is the same as GetMartketPx but the request is bound to ctx.
+func (api *InfoAPI) GetMartketPxWithContext(ctx context.Context, coin string) (float64, error) {
+	allMids, err := api.GetAllMidsWithContext(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -312,12 +591,20 @@ func (api *InfoAPI) GetMartketPx(coin string) (float64, error) {
 //
 //	api.GetSpotMarketPx("HYPE")
 func (api *InfoAPI) GetSpotMarketPx(coin string) (float64, error) {
-	spotPrices, err := api.GetAllSpotPrices()
+	return api.GetSpotMarketPxWithContext(context.Background(), coin)
+}
+
+// GetSpotMarketPxWithContext is the same as GetSpotMarketPx but the request is bound to ctx.
+func (api *InfoAPI) GetSpotMarketPxWithContext(ctx context.Context, coin string) (float64, error) {
+	asset, err := api.assets.ResolveSpot(ctx, coin)
+	if err != nil {
+		return 0, err
+	}
+	spotPrices, err := api.GetAllSpotPricesWithContext(ctx)
 	if err != nil {
 		return 0, err
 	}
-	spotName := api.spotMeta[coin].SpotName
-	parsed, err := strconv.ParseFloat((*spotPrices)[spotName], 32)
+	parsed, err := strconv.ParseFloat((*spotPrices)[asset.Name], 32)
 	if err != nil {
 		return 0, err
 	}
@@ -327,8 +614,13 @@ func (api *InfoAPI) GetSpotMarketPx(coin string) (float64, error) {
 // Helper function to get the withdrawals of a given address
 // By default returns last 90 days
 func (api *InfoAPI) GetWithdrawals(address string) (*[]Withdrawal, error) {
+	return api.GetWithdrawalsWithContext(context.Background(), address)
+}
+
+// GetWithdrawalsWithContext is the same as GetWithdrawals but the request is bound to ctx.
+func (api *InfoAPI) GetWithdrawalsWithContext(ctx context.Context, address string) (*[]Withdrawal, error) {
 	startTime, endTime := GetDefaultTimeRange()
-	updates, err := api.GetNonFundingUpdates(address, startTime, endTime)
+	updates, err := api.GetNonFundingUpdatesWithContext(ctx, address, startTime, endTime)
 	if err != nil {
 		return nil, err
 	}
@@ -352,14 +644,24 @@ func (api *InfoAPI) GetWithdrawals(address string) (*[]Withdrawal, error) {
 // The same as GetWithdrawals but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountWithdrawals() (*[]Withdrawal, error) {
-	return api.GetWithdrawals(api.AccountAddress())
+	return api.GetAccountWithdrawalsWithContext(context.Background())
+}
+
+// GetAccountWithdrawalsWithContext is the same as GetAccountWithdrawals but the request is bound to ctx.
+func (api *InfoAPI) GetAccountWithdrawalsWithContext(ctx context.Context) (*[]Withdrawal, error) {
+	return api.GetWithdrawalsWithContext(ctx, api.AccountAddress())
 }
 
 // Helper function to get the deposits of the given address
 // By default returns last 90 days
 func (api *InfoAPI) GetDeposits(address string) (*[]Deposit, error) {
+	return api.GetDepositsWithContext(context.Background(), address)
+}
+
+// GetDepositsWithContext is the same as GetDeposits but the request is bound to ctx.
+func (api *InfoAPI) GetDepositsWithContext(ctx context.Context, address string) (*[]Deposit, error) {
 	startTime, endTime := GetDefaultTimeRange()
-	updates, err := api.GetNonFundingUpdates(address, startTime, endTime)
+	updates, err := api.GetNonFundingUpdatesWithContext(ctx, address, startTime, endTime)
 	if err != nil {
 		return nil, err
 	}
@@ -381,14 +683,24 @@ func (api *InfoAPI) GetDeposits(address string) (*[]Deposit, error) {
 // The same as GetDeposits but user is set to the account address
 // Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
 func (api *InfoAPI) GetAccountDeposits() (*[]Deposit, error) {
-	return api.GetDeposits(api.AccountAddress())
+	return api.GetAccountDepositsWithContext(context.Background())
+}
+
+// GetAccountDepositsWithContext is the same as GetAccountDeposits but the request is bound to ctx.
+func (api *InfoAPI) GetAccountDepositsWithContext(ctx context.Context) (*[]Deposit, error) {
+	return api.GetDepositsWithContext(ctx, api.AccountAddress())
 }
 
 // Helper function to build a map of asset names to asset info
 // It is used to get the assetId for a given asset name
 func (api *InfoAPI) BuildMetaMap() (map[string]AssetInfo, error) {
+	return api.BuildMetaMapWithContext(context.Background())
+}
+
+// BuildMetaMapWithContext is the same as BuildMetaMap but the request is bound to ctx.
+func (api *InfoAPI) BuildMetaMapWithContext(ctx context.Context) (map[string]AssetInfo, error) {
 	metaMap := make(map[string]AssetInfo)
-	result, err := api.GetMeta()
+	result, err := api.GetMetaWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -404,11 +716,19 @@ func (api *InfoAPI) BuildMetaMap() (map[string]AssetInfo, error) {
 // Helper function to build a map of asset names to asset info
 // It is used to get the assetId for a given asset name
 func (api *InfoAPI) BuildSpotMetaMap() (map[string]AssetInfo, error) {
-	spotMeta, err := api.GetSpotMeta()
+	return api.BuildSpotMetaMapWithContext(context.Background())
+}
+
+// BuildSpotMetaMapWithContext is the same as BuildSpotMetaMap but the request is bound to ctx.
+func (api *InfoAPI) BuildSpotMetaMapWithContext(ctx context.Context) (map[string]AssetInfo, error) {
+	spotMeta, err := api.GetSpotMetaWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return buildSpotMetaMap(spotMeta), nil
+}
 
+func buildSpotMetaMap(spotMeta *SpotMeta) map[string]AssetInfo {
 	tokenMap := make(map[int]struct {
 		name        string
 		szDecimals  int
@@ -439,16 +759,37 @@ func (api *InfoAPI) BuildSpotMetaMap() (map[string]AssetInfo, error) {
 			}
 		}
 	}
-	return metaMap, nil
+	return metaMap
+}
+
+// buildSpotTokenMap maps the spot token names to the token info used by spot transfers.
+func buildSpotTokenMap(spotMeta *SpotMeta) map[string]SpotTokenInfo {
+	tokenMap := make(map[string]SpotTokenInfo, len(spotMeta.Tokens))
+	for _, token := range spotMeta.Tokens {
+		tokenMap[token.Name] = SpotTokenInfo{
+			Name:        token.Name,
+			Index:       token.Index,
+			TokenID:     token.TokenID,
+			SzDecimals:  token.SzDecimals,
+			WeiDecimals: token.WeiDecimals,
+		}
+	}
+	return tokenMap
 }
 
 // MinLotSizeMap returns a map from asset symbol to its minimum lot size step (1/10^szDecimals).
 func (api *InfoAPI) MinLotSizeMap() (map[string]float64, error) {
-	meta, err := api.BuildMetaMap()
+	return api.MinLotSizeMapWithContext(context.Background())
+}
+
+// MinLotSizeMapWithContext is the same as MinLotSizeMap but the request is bound to ctx.
+func (api *InfoAPI) MinLotSizeMapWithContext(ctx context.Context) (map[string]float64, error) {
+	table, err := api.assets.load(ctx)
 	if err != nil {
 		return nil, err
 	}
-	maps.Copy(meta, api.spotMeta)
+	meta, spotMeta := table.legacyMaps()
+	maps.Copy(meta, spotMeta)
 
 	res := make(map[string]float64, len(meta))
 	for asset, info := range meta {
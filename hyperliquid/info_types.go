@@ -1,5 +1,11 @@
 package hyperliquid
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
 // Base request for /info
 type InfoRequest struct {
 	User      string `json:"user,omitempty"`
@@ -8,6 +14,19 @@ type InfoRequest struct {
 	Coin      string `json:"coin,omitempty"`
 	StartTime int64  `json:"startTime,omitempty"`
 	EndTime   int64  `json:"endTime,omitempty"`
+	Dex       string `json:"dex,omitempty"`
+}
+
+type VaultDetailsRequest struct {
+	Typez        string `json:"type"`
+	VaultAddress string `json:"vaultAddress"`
+	User         string `json:"user,omitempty"`
+}
+
+type MaxBuilderFeeRequest struct {
+	Typez   string `json:"type"`
+	User    string `json:"user"`
+	Builder string `json:"builder"`
 }
 
 type UserStateRequest struct {
@@ -20,6 +39,16 @@ type Asset struct {
 	SzDecimals   int    `json:"szDecimals"`
 	MaxLeverage  int    `json:"maxLeverage"`
 	OnlyIsolated bool   `json:"onlyIsolated"`
+	IsDelisted   bool   `json:"isDelisted,omitempty"`
+}
+
+// PerpDex is a HIP-3 perp dex deployed by a builder.
+// The first perp dex is reported as null, so its Name is empty.
+type PerpDex struct {
+	Name          string  `json:"name"`
+	FullName      string  `json:"full_name"`
+	Deployer      string  `json:"deployer"`
+	OracleUpdater *string `json:"oracle_updater"`
 }
 
 type UserState struct {
@@ -230,3 +259,121 @@ type Market struct {
 	TotalSupply       string `json:"totalSupply,omitempty"`
 	DayBaseVlm        string `json:"dayBaseVlm,omitempty"`
 }
+
+type ExtraAgent struct {
+	Name       string `json:"name"`
+	Address    string `json:"address"`
+	ValidUntil int64  `json:"validUntil"`
+}
+
+// SubAccount is a subaccount of a master account with its perp and spot states.
+type SubAccount struct {
+	Name           string        `json:"name"`
+	SubAccountUser string        `json:"subAccountUser"`
+	Master         string        `json:"master"`
+	UserState      UserState     `json:"clearinghouseState"`
+	UserStateSpot  UserStateSpot `json:"spotState"`
+}
+
+// VaultDetails describes a vault, its followers and its performance.
+type VaultDetails struct {
+	Name                  string            `json:"name"`
+	VaultAddress          string            `json:"vaultAddress"`
+	Leader                string            `json:"leader"`
+	Description           string            `json:"description"`
+	Portfolio             VaultPortfolio    `json:"portfolio"`
+	Apr                   float64           `json:"apr"`
+	FollowerState         *VaultFollower    `json:"followerState"` // state of the user of the request, nil if not a follower
+	LeaderFraction        float64           `json:"leaderFraction"`
+	LeaderCommission      float64           `json:"leaderCommission"`
+	Followers             []VaultFollower   `json:"followers"`
+	MaxDistributable      float64           `json:"maxDistributable"`
+	MaxWithdrawable       float64           `json:"maxWithdrawable"`
+	IsClosed              bool              `json:"isClosed"`
+	Relationship          VaultRelationship `json:"relationship"`
+	AllowDeposits         bool              `json:"allowDeposits"`
+	AlwaysCloseOnWithdraw bool              `json:"alwaysCloseOnWithdraw"`
+}
+
+type VaultFollower struct {
+	User           string  `json:"user"`
+	VaultEquity    float64 `json:"vaultEquity,string"`
+	Pnl            float64 `json:"pnl,string"`
+	AllTimePnl     float64 `json:"allTimePnl,string"`
+	DaysFollowing  int     `json:"daysFollowing"`
+	VaultEntryTime int64   `json:"vaultEntryTime"`
+	LockupUntil    int64   `json:"lockupUntil"` // withdrawals are locked until this time in milliseconds
+}
+
+// VaultRelationship is "normal", "parent" with the child vaults or "child".
+type VaultRelationship struct {
+	Type string `json:"type"`
+	Data *struct {
+		ChildAddresses []string `json:"childAddresses"`
+	} `json:"data,omitempty"`
+}
+
+// VaultPortfolio maps a period ("day", "week", "month", "allTime", "perpDay"...) to the vault history over it.
+type VaultPortfolio map[string]VaultPortfolioPeriod
+
+type VaultPortfolioPeriod struct {
+	AccountValueHistory []HistoryPoint `json:"accountValueHistory"`
+	PnlHistory          []HistoryPoint `json:"pnlHistory"`
+	Vlm                 float64        `json:"vlm,string"`
+}
+
+// UnmarshalJSON implements custom unmarshaling for VaultPortfolio:
+// the portfolio is a list of [period, history] pairs.
+func (portfolio *VaultPortfolio) UnmarshalJSON(data []byte) error {
+	var pairs [][2]json.RawMessage
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return fmt.Errorf("VaultPortfolio: %w", err)
+	}
+	*portfolio = make(VaultPortfolio, len(pairs))
+	for _, pair := range pairs {
+		var period string
+		var history VaultPortfolioPeriod
+		if err := json.Unmarshal(pair[0], &period); err != nil {
+			return fmt.Errorf("VaultPortfolio: %w", err)
+		}
+		if err := json.Unmarshal(pair[1], &history); err != nil {
+			return fmt.Errorf("VaultPortfolio: %w", err)
+		}
+		(*portfolio)[period] = history
+	}
+	return nil
+}
+
+// HistoryPoint is a value at a time in milliseconds.
+type HistoryPoint struct {
+	Time  int64
+	Value float64
+}
+
+// UnmarshalJSON implements custom unmarshaling for HistoryPoint: the point is a [time, "value"] pair.
+func (point *HistoryPoint) UnmarshalJSON(data []byte) error {
+	var raw [2]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("HistoryPoint: %w", err)
+	}
+	if err := json.Unmarshal(raw[0], &point.Time); err != nil {
+		return fmt.Errorf("HistoryPoint: %w", err)
+	}
+	var value string
+	if err := json.Unmarshal(raw[1], &value); err != nil {
+		return fmt.Errorf("HistoryPoint: %w", err)
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("HistoryPoint: %w", err)
+	}
+	point.Value = parsed
+	return nil
+}
+
+// UserVaultEquity is the equity of a user in a vault.
+type UserVaultEquity struct {
+	VaultAddress         string  `json:"vaultAddress"`
+	Equity               float64 `json:"equity,string"`
+	LockedUntilTimestamp int64   `json:"lockedUntilTimestamp"`
+}
@@ -0,0 +1,79 @@
+package hyperliquid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoTopUpNeeded is returned by TopUpIsolatedMargin when the liquidation price is already far enough:
+// no margin is added and no request is sent.
+var ErrNoTopUpNeeded = errors.New("liquidation price is already far enough")
+
+// IsolatedMarginTopUp returns the USD amount to add to an isolated position so its liquidation price
+// is at least distance (a fraction, e.g. 0.2 for 20%) away from the mark price, rounded up to the cent.
+// It returns 0 if the liquidation price is already far enough.
+//
+// The mark price is PositionValue / |Szi|. Hyperliquid computes the liquidation price as
+//
+//	liquidationPx = markPx - side * marginAvailable / |Szi| / (1 - l * side)
+//
+// with side 1 for a long and -1 for a short and l = 1 / (2 * MaxLeverage) the maintenance margin rate,
+// so adding margin moves the liquidation price by side * amount / |Szi| / (1 - l * side) away from the mark price.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/trading/liquidations
+func IsolatedMarginTopUp(position Position, distance float64) (float64, error) {
+	if distance <= 0 || distance >= 1 {
+		return 0, fmt.Errorf("distance must be between 0 and 1, got %v", distance)
+	}
+	if position.Leverage.Type != "isolated" {
+		return 0, fmt.Errorf("position %s is not isolated", position.Coin)
+	}
+	size := math.Abs(position.Szi)
+	if size == 0 || position.MaxLeverage <= 0 {
+		return 0, fmt.Errorf("position %s is empty", position.Coin)
+	}
+	side := 1.0
+	if position.Szi < 0 {
+		side = -1
+	}
+	markPx := position.PositionValue / size
+	targetPx := markPx * (1 - side*distance)
+	maintenance := 1 / (2 * float64(position.MaxLeverage))
+	amount := side * (position.LiquidationPx - targetPx) * size * (1 - maintenance*side)
+	if amount <= 0 {
+		return 0, nil
+	}
+	return math.Ceil(amount*100) / 100, nil
+}
+
+// Add margin to the isolated position of coin until its liquidation price is
+// at least distance (a fraction of the mark price) away from the mark price.
+// It returns ErrNoTopUpNeeded if the liquidation price is already far enough, see IsolatedMarginTopUp.
+func (api *ExchangeAPI) TopUpIsolatedMargin(coin string, distance float64) (*DefaultExchangeResponse, error) {
+	return api.TopUpIsolatedMarginWithContext(context.Background(), coin, distance)
+}
+
+// TopUpIsolatedMarginWithContext is the same as TopUpIsolatedMargin but the requests are bound to ctx.
+func (api *ExchangeAPI) TopUpIsolatedMarginWithContext(ctx context.Context, coin string, distance float64) (*DefaultExchangeResponse, error) {
+	state, err := api.infoAPI.GetUserStateWithContext(ctx, api.tradingAddress())
+	if err != nil {
+		api.debug("Error GetUserState: %s", err)
+		return nil, err
+	}
+	for _, position := range state.AssetPositions {
+		item := position.Position
+		if coin != item.Coin {
+			continue
+		}
+		amount, err := IsolatedMarginTopUp(item, distance)
+		if err != nil {
+			return nil, err
+		}
+		if amount == 0 {
+			return nil, ErrNoTopUpNeeded
+		}
+		return api.UpdateIsolatedMarginWithContext(ctx, coin, IsBuy(item.Szi), amount)
+	}
+	return nil, APIError{Message: fmt.Sprintf("No position found for %s", coin)}
+}
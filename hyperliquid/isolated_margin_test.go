@@ -0,0 +1,105 @@
+package hyperliquid
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestIsolatedMarginTopUp(t *testing.T) {
+	isolated := Leverage{Type: "isolated", Value: 10}
+	tests := []struct {
+		name     string
+		position Position
+		distance float64
+		want     float64
+		wantErr  bool
+	}{
+		{
+			name:     "long",
+			position: Position{Coin: "ETH", Leverage: isolated, Szi: 1, PositionValue: 2000, LiquidationPx: 1800, MaxLeverage: 25},
+			distance: 0.2,
+			want:     196,
+		},
+		{
+			name:     "short",
+			position: Position{Coin: "ETH", Leverage: isolated, Szi: -2, PositionValue: 4000, LiquidationPx: 2200, MaxLeverage: 25},
+			distance: 0.2,
+			want:     408,
+		},
+		{
+			name:     "rounded up to the cent",
+			position: Position{Coin: "ETH", Leverage: isolated, Szi: 0.001, PositionValue: 2, LiquidationPx: 1800, MaxLeverage: 25},
+			distance: 0.2,
+			want:     0.2,
+		},
+		{
+			name:     "far enough",
+			position: Position{Coin: "ETH", Leverage: isolated, Szi: 1, PositionValue: 2000, LiquidationPx: 1500, MaxLeverage: 25},
+			distance: 0.2,
+			want:     0,
+		},
+		{
+			name:     "cross",
+			position: Position{Coin: "ETH", Leverage: Leverage{Type: "cross", Value: 10}, Szi: 1, PositionValue: 2000, LiquidationPx: 1800, MaxLeverage: 25},
+			distance: 0.2,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid distance",
+			position: Position{Coin: "ETH", Leverage: isolated, Szi: 1, PositionValue: 2000, LiquidationPx: 1800, MaxLeverage: 25},
+			distance: 1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IsolatedMarginTopUp(tt.position, tt.distance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsolatedMarginTopUp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("IsolatedMarginTopUp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExchangeAPI_TopUpIsolatedMargin(t *testing.T) {
+	exchange := &testExchange{
+		Info: func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"assetPositions":[{"position":{"coin":"ETH","entryPx":"2100.0","leverage":{"type":"isolated","value":10},` +
+				`"liquidationPx":"2200.0","marginUsed":"400.0","positionValue":"4000.0","returnOnEquity":"0.0","szi":"-2.0",` +
+				`"unrealizedPnl":"200.0","maxLeverage":25,"cumFunding":{"allTime":"0.0","sinceOpen":"0.0","sinceChange":"0.0"}},"type":"oneWay"}]}`))
+		},
+	}
+	exchangeAPI, _ := newTestExchangeAPI(t, exchange, &MetaSnapshot{
+		Meta:     map[string]AssetInfo{"ETH": {SzDecimals: 4, AssetId: 1}},
+		SpotMeta: map[string]AssetInfo{},
+	})
+
+	if _, err := exchangeAPI.TopUpIsolatedMargin("ETH", 0.2); err != nil {
+		t.Fatalf("TopUpIsolatedMargin() error = %v", err)
+	}
+	action := exchange.action()
+	if action["type"] != "updateIsolatedMargin" || action["asset"] != float64(1) || action["isBuy"] != false || action["ntli"] != float64(408000000) {
+		t.Errorf("updateIsolatedMargin action = %v", action)
+	}
+
+	// a negative amount removes margin
+	if _, err := exchangeAPI.UpdateIsolatedMargin("ETH", true, -12.345678); err != nil {
+		t.Fatalf("UpdateIsolatedMargin() error = %v", err)
+	}
+	action = exchange.action()
+	if action["isBuy"] != true || action["ntli"] != float64(-12345678) {
+		t.Errorf("updateIsolatedMargin action = %v", action)
+	}
+
+	exchange.Request = ExchangeRequest{}
+	if res, err := exchangeAPI.TopUpIsolatedMargin("ETH", 0.05); res != nil || !errors.Is(err, ErrNoTopUpNeeded) || exchange.Request.Action != nil {
+		t.Errorf("TopUpIsolatedMargin() far enough = %v, %v, sent %v", res, err, exchange.Request.Action)
+	}
+	if _, err := exchangeAPI.TopUpIsolatedMargin("BTC", 0.2); err == nil {
+		t.Errorf("TopUpIsolatedMargin() without position error = nil, want error")
+	}
+}
@@ -0,0 +1,53 @@
+package hyperliquid
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/google/uuid"
+)
+
+// ErrKeystorePassphrase is returned when a keystore can not be decrypted with the passphrase.
+var ErrKeystorePassphrase = keystore.ErrDecrypt
+
+// NewPKeyManagerFromKeystore creates a PKeyManager from an Ethereum V3 JSON keystore
+// (as written by geth, Foundry cast or MetaMask exports) encrypted with passphrase.
+func NewPKeyManagerFromKeystore(keystoreJSON []byte, passphrase string) (*PKeyManager, error) {
+	key, err := keystore.DecryptKey(keystoreJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return newPKeyManager(key.PrivateKey)
+}
+
+// NewPKeyManagerFromKeystoreFile is the same as NewPKeyManagerFromKeystore but reads the keystore from path.
+func NewPKeyManagerFromKeystoreFile(path string, passphrase string) (*PKeyManager, error) {
+	keystoreJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPKeyManagerFromKeystore(keystoreJSON, passphrase)
+}
+
+// EncryptKeystore exports the private key as an Ethereum V3 JSON keystore encrypted with passphrase,
+// using the standard scrypt parameters of geth.
+func (km *PKeyManager) EncryptKeystore(passphrase string) ([]byte, error) {
+	return km.encryptKeystore(passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+func (km *PKeyManager) encryptKeystore(passphrase string, scryptN int, scryptP int) ([]byte, error) {
+	if km.privateKey == nil {
+		return nil, fmt.Errorf("private key is zeroed")
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	key := &keystore.Key{
+		Id:         id,
+		Address:    km.PublicAddress(),
+		PrivateKey: km.privateKey,
+	}
+	return keystore.EncryptKey(key, passphrase, scryptN, scryptP)
+}
@@ -0,0 +1,82 @@
+package hyperliquid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test vector of the Web3 Secret Storage Definition
+const pbkdf2Keystore = `{
+	"crypto": {
+		"cipher": "aes-128-ctr",
+		"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+		"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+		"kdf": "pbkdf2",
+		"kdfparams": {
+			"c": 262144,
+			"dklen": 32,
+			"prf": "hmac-sha256",
+			"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+		},
+		"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+	},
+	"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+	"version": 3
+}`
+
+func TestNewPKeyManagerFromKeystore(t *testing.T) {
+	manager, err := NewPKeyManagerFromKeystore([]byte(pbkdf2Keystore), "testpassword")
+	if err != nil {
+		t.Fatalf("NewPKeyManagerFromKeystore() error = %v", err)
+	}
+	want, _ := NewPKeyManager("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	if manager.PublicAddress() != want.PublicAddress() {
+		t.Errorf("PublicAddress() = %v, want %v", manager.PublicAddress(), want.PublicAddress())
+	}
+	if _, err := NewPKeyManagerFromKeystore([]byte(pbkdf2Keystore), "wrong"); !errors.Is(err, ErrKeystorePassphrase) {
+		t.Errorf("NewPKeyManagerFromKeystore() error = %v, want %v", err, ErrKeystorePassphrase)
+	}
+
+	// scrypt round trip with light parameters
+	keystoreJSON, err := want.encryptKeystore("passphrase", 1<<4, 1)
+	if err != nil {
+		t.Fatalf("encryptKeystore() error = %v", err)
+	}
+	decrypted, err := NewPKeyManagerFromKeystore(keystoreJSON, "passphrase")
+	if err != nil {
+		t.Fatalf("NewPKeyManagerFromKeystore(scrypt) error = %v", err)
+	}
+	if !decrypted.PrivateECDSA().Equal(want.PrivateECDSA()) {
+		t.Errorf("NewPKeyManagerFromKeystore(scrypt) returned another key")
+	}
+}
+
+func TestNewPKeyManagerFromSources(t *testing.T) {
+	want, _ := NewPKeyManager(testPrivateKey)
+
+	t.Setenv("HL_TEST_PRIVATE_KEY", testPrivateKey+"\n")
+	fromEnv, err := NewPKeyManagerFromEnv("HL_TEST_PRIVATE_KEY")
+	if err != nil || fromEnv.PublicAddress() != want.PublicAddress() {
+		t.Errorf("NewPKeyManagerFromEnv() = %v, %v", fromEnv, err)
+	}
+	if _, err := NewPKeyManagerFromEnv("HL_TEST_MISSING_KEY"); err == nil {
+		t.Errorf("NewPKeyManagerFromEnv() of an unset variable error = nil, want error")
+	}
+
+	fromReader, err := NewPKeyManagerFromReader(strings.NewReader(testPrivateKey))
+	if err != nil || fromReader.PublicAddress() != want.PublicAddress() {
+		t.Errorf("NewPKeyManagerFromReader() = %v, %v", fromReader, err)
+	}
+
+	fromReader.Zero()
+	if fromReader.PrivateECDSA() != nil {
+		t.Errorf("PrivateECDSA() after Zero() = %v, want nil", fromReader.PrivateECDSA())
+	}
+	if fromReader.PublicAddress() != want.PublicAddress() {
+		t.Errorf("PublicAddress() after Zero() = %v, want %v", fromReader.PublicAddress(), want.PublicAddress())
+	}
+	if _, err := NewPrivateKeySigner(fromReader).SignDigest(t.Context(), make([]byte, 32)); err == nil {
+		t.Errorf("SignDigest() after Zero() error = nil, want error")
+	}
+}
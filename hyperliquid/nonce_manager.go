@@ -0,0 +1,176 @@
+package hyperliquid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofrs/flock"
+)
+
+// ErrNonceWindow is returned when the next nonce of a signer would be too far ahead of the current time
+// to be accepted by Hyperliquid, e.g. after more than NONCE_MAX_AHEAD of sustained bursts,
+// or when a prepared request is submitted more than NONCE_MAX_BEHIND after it was prepared.
+var ErrNonceWindow = errors.New("nonce is outside of the accepted window")
+
+// NonceManager hands out the nonces of the exchange requests.
+//
+// Hyperliquid tracks nonces per signer (the master account or the agent that signs):
+// it keeps the 100 highest nonces of every signer and accepts a new nonce only if it is
+// higher than the smallest of them, unused, and within the window of
+// NONCE_MAX_BEHIND before and NONCE_MAX_AHEAD after the block time.
+// A NonceManager returns strictly increasing timestamps in milliseconds for each signer
+// so requests of different signers do not share one sequence.
+type NonceManager interface {
+	// NextNonce returns a new nonce for the signer address.
+	NextNonce(signer common.Address) (uint64, error)
+}
+
+// defaultNonceManager is shared by the clients that are not given a NonceManager,
+// so two clients signing with the same key in one process never collide.
+var defaultNonceManager = NewMemoryNonceManager()
+
+// WithNonceManager sets the NonceManager of the exchange requests.
+// Use a FileNonceManager when several processes sign with the same key.
+func WithNonceManager(manager NonceManager) ClientOption {
+	return func(client *Client) {
+		if manager != nil {
+			client.nonceManager = manager
+		}
+	}
+}
+
+// signerAddress returns the address of the key that signs the requests of the API.
+// Requests prepared without a signer (see PrepareL1Action) are expected to be signed by the account address,
+// which must then be the address of the key that signs them: the agent if an agent signs.
+func (api *ExchangeAPI) signerAddress() common.Address {
+	if api.signer != nil {
+		return api.signer.Address()
+	}
+	return common.HexToAddress(api.AccountAddress())
+}
+
+// nonce returns the next nonce of the signer of the API, see signerAddress.
+func (api *ExchangeAPI) nonce() (uint64, error) {
+	nonce, err := api.nonceManager.NextNonce(api.signerAddress())
+	if err != nil {
+		api.debug("Error getting nonce: %s", err)
+	}
+	return nonce, err
+}
+
+// nextNonce returns the nonce following last: the current time, or last + 1 during bursts.
+func nextNonce(last uint64, now time.Time) (uint64, error) {
+	nonce := uint64(now.UnixMilli())
+	if nonce <= last {
+		nonce = last + 1
+	}
+	if nonce > uint64(now.Add(NONCE_MAX_AHEAD).UnixMilli()) {
+		return 0, ErrNonceWindow
+	}
+	return nonce, nil
+}
+
+// MemoryNonceManager is a NonceManager that keeps the last nonce of every signer in memory.
+type MemoryNonceManager struct {
+	mu   sync.Mutex
+	last map[common.Address]uint64
+}
+
+func NewMemoryNonceManager() *MemoryNonceManager {
+	return &MemoryNonceManager{
+		last: map[common.Address]uint64{},
+	}
+}
+
+func (manager *MemoryNonceManager) NextNonce(signer common.Address) (uint64, error) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	nonce, err := nextNonce(manager.last[signer], time.Now())
+	if err != nil {
+		return 0, err
+	}
+	manager.last[signer] = nonce
+	return nonce, nil
+}
+
+// FileNonceManager is a NonceManager that persists the last nonce of every signer in a directory,
+// so the sequence survives restarts and is shared by the processes using the same directory.
+// Every signer has its own file, guarded by an advisory lock (flock) on a lock file next to it.
+type FileNonceManager struct {
+	mu          sync.Mutex
+	dir         string
+	lockTimeout time.Duration
+}
+
+// NewFileNonceManager returns a FileNonceManager storing the nonces in dir, which is created if needed.
+func NewFileNonceManager(dir string) (*FileNonceManager, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileNonceManager{
+		dir:         dir,
+		lockTimeout: 5 * time.Second,
+	}, nil
+}
+
+func (manager *FileNonceManager) NextNonce(signer common.Address) (uint64, error) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	path := filepath.Join(manager.dir, strings.ToLower(signer.Hex())+".nonce")
+	unlock, err := manager.lock(path + ".lock")
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	var last uint64
+	data, err := os.ReadFile(path)
+	if err == nil {
+		last, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid nonce file %s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+	nonce, err := nextNonce(last, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	// write and rename so a crash never leaves a truncated file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(nonce, 10)), 0o600); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// lock takes the lock of the lock file, waiting for other processes to release it.
+// The lock file is never removed: the lock is held by the open file,
+// so it is released by the system if the process crashes.
+func (manager *FileNonceManager) lock(path string) (func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), manager.lockTimeout)
+	defer cancel()
+	fileLock := flock.New(path)
+	locked, err := fileLock.TryLockContext(ctx, 5*time.Millisecond)
+	if !locked {
+		fileLock.Close()
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("timeout waiting for nonce lock %s", path)
+	}
+	return func() { fileLock.Unlock() }, nil
+}
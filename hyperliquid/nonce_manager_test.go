@@ -0,0 +1,112 @@
+package hyperliquid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestMemoryNonceManager(t *testing.T) {
+	manager := NewMemoryNonceManager()
+	alice := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	bob := common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+	var mu sync.Mutex
+	seen := map[uint64]bool{}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				nonce, err := manager.NextNonce(alice)
+				if err != nil {
+					t.Errorf("NextNonce() error = %v", err)
+					return
+				}
+				mu.Lock()
+				if seen[nonce] {
+					t.Errorf("NextNonce() returned %d twice", nonce)
+				}
+				seen[nonce] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// bob has his own sequence starting at the current time
+	nonce, _ := manager.NextNonce(bob)
+	if now := uint64(time.Now().UnixMilli()); nonce > now {
+		t.Errorf("NextNonce(bob) = %d, want at most %d", nonce, now)
+	}
+
+	manager.last[alice] = uint64(time.Now().Add(NONCE_MAX_AHEAD).UnixMilli()) + 1000
+	if _, err := manager.NextNonce(alice); !errors.Is(err, ErrNonceWindow) {
+		t.Errorf("NextNonce() error = %v, want %v", err, ErrNonceWindow)
+	}
+}
+
+func TestFileNonceManager(t *testing.T) {
+	dir := t.TempDir()
+	signer := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	// two managers on the same directory behave like two processes
+	first, err := NewFileNonceManager(dir)
+	if err != nil {
+		t.Fatalf("NewFileNonceManager() error = %v", err)
+	}
+	second, _ := NewFileNonceManager(dir)
+
+	var last uint64
+	for i := range 20 {
+		manager := first
+		if i%2 == 1 {
+			manager = second
+		}
+		nonce, err := manager.NextNonce(signer)
+		if err != nil {
+			t.Fatalf("NextNonce() error = %v", err)
+		}
+		if nonce <= last {
+			t.Fatalf("NextNonce() = %d after %d", nonce, last)
+		}
+		last = nonce
+	}
+
+	// concurrent processes never share a nonce
+	seen := map[uint64]bool{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, manager := range []*FileNonceManager{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				nonce, err := manager.NextNonce(signer)
+				if err != nil {
+					t.Errorf("NextNonce() error = %v", err)
+					return
+				}
+				mu.Lock()
+				if seen[nonce] || nonce <= last {
+					t.Errorf("NextNonce() returned %d twice or after %d", nonce, last)
+				}
+				seen[nonce] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	for nonce := range seen {
+		last = max(last, nonce)
+	}
+
+	// the sequence survives a restart
+	restarted, _ := NewFileNonceManager(dir)
+	if nonce, _ := restarted.NextNonce(signer); nonce <= last {
+		t.Errorf("NextNonce() after restart = %d, want more than %d", nonce, last)
+	}
+}
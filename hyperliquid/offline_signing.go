@@ -0,0 +1,207 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// UnsignedAction is an exchange request waiting for its signature.
+// It is JSON serializable, so it can be carried to an offline (cold) signer:
+// sign TypedData as EIP-712 (or sign Digest directly) and pass the signature
+// to SubmitSigned together with the UnsignedAction.
+// The nonce is part of the signed data, so the action must be submitted
+// before the nonce falls out of the window accepted by Hyperliquid:
+// SubmitSigned returns ErrNonceWindow once it is older than NONCE_MAX_BEHIND.
+//
+// Only PrepareBulkOrders and PrepareSpotSend may send a request, to load the metadata,
+// so only they have a WithContext variant: the other Prepare methods make no network request.
+//
+// Hyperliquid tracks the nonces per signing key. Without a Signer, the nonces are taken from
+// the sequence of the account address, so SetAccountAddress must be given the address of the key
+// that signs the prepared requests (the agent address if an agent signs them).
+// That address is recorded as Signer and SubmitSigned rejects the signatures of any other key.
+type UnsignedAction struct {
+	Request   ExchangeRequest    `json:"request"`
+	TypedData apitypes.TypedData `json:"typedData"`
+	Digest    hexutil.Bytes      `json:"digest"`
+	Signer    common.Address     `json:"signer"`
+}
+
+// PrepareL1Action builds the unsigned request of any L1 action (order, cancel, modify, leverage update...),
+// on behalf of the vault address if one is set.
+func (api *ExchangeAPI) PrepareL1Action(action any) (*UnsignedAction, error) {
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	srequest, err := api.BuildEIP712Message(action, nonce)
+	if err != nil {
+		return nil, err
+	}
+	var vaultAddress *string
+	if api.vaultAddress != "" {
+		// copy, so a later SetVaultAddress does not change the prepared request
+		v := api.vaultAddress
+		vaultAddress = &v
+	}
+	return newUnsignedAction(action, nonce, vaultAddress, api.signerAddress(), srequest)
+}
+
+// PrepareBulkOrders builds the unsigned request of BulkOrders.
+func (api *ExchangeAPI) PrepareBulkOrders(requests []OrderRequest, grouping Grouping, isSpot bool) (*UnsignedAction, error) {
+	return api.PrepareBulkOrdersWithContext(context.Background(), requests, grouping, isSpot)
+}
+
+// PrepareBulkOrdersWithContext is the same as PrepareBulkOrders but the metadata request is bound to ctx.
+func (api *ExchangeAPI) PrepareBulkOrdersWithContext(ctx context.Context, requests []OrderRequest, grouping Grouping, isSpot bool) (*UnsignedAction, error) {
+	action, err := api.buildOrderAction(ctx, requests, grouping, isSpot)
+	if err != nil {
+		return nil, err
+	}
+	return api.PrepareL1Action(action)
+}
+
+// PrepareBulkCancelOrders builds the unsigned request of BulkCancelOrders.
+func (api *ExchangeAPI) PrepareBulkCancelOrders(cancels []CancelOidWire) (*UnsignedAction, error) {
+	return api.PrepareL1Action(CancelOidOrderAction{
+		Type:    "cancel",
+		Cancels: cancels,
+	})
+}
+
+// PrepareWithdraw builds the unsigned request of Withdraw.
+func (api *ExchangeAPI) PrepareWithdraw(destination string, amount float64) (*UnsignedAction, error) {
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	return api.prepareUserSignedAction(api.newWithdrawAction(destination, amount, nonce), nonce)
+}
+
+// PrepareUsdClassTransfer builds the unsigned request of TransferUsdClass.
+func (api *ExchangeAPI) PrepareUsdClassTransfer(amount float64, toPerp bool, subaccount *string) (*UnsignedAction, error) {
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	return api.prepareUserSignedAction(api.newUsdClassTransferAction(amount, toPerp, subaccount, nonce), nonce)
+}
+
+// PrepareUsdSend builds the unsigned request of UsdSend.
+func (api *ExchangeAPI) PrepareUsdSend(destination string, amount float64) (*UnsignedAction, error) {
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	return api.prepareUserSignedAction(api.newUsdSendAction(destination, amount, nonce), nonce)
+}
+
+// PrepareSpotSend builds the unsigned request of SpotSend.
+func (api *ExchangeAPI) PrepareSpotSend(destination string, token string, amount float64) (*UnsignedAction, error) {
+	return api.PrepareSpotSendWithContext(context.Background(), destination, token, amount)
+}
+
+// PrepareSpotSendWithContext is the same as PrepareSpotSend but the metadata request is bound to ctx.
+func (api *ExchangeAPI) PrepareSpotSendWithContext(ctx context.Context, destination string, token string, amount float64) (*UnsignedAction, error) {
+	info, err := api.infoAPI.assets.SpotToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := api.nonce()
+	if err != nil {
+		return nil, err
+	}
+	return api.prepareUserSignedAction(api.newSpotSendAction(destination, info, amount, nonce), nonce)
+}
+
+// prepareUserSignedAction builds the unsigned request of a user-signed action, see BuildUserSignedEIP712Message.
+// User-signed actions are never sent on behalf of a vault.
+func (api *ExchangeAPI) prepareUserSignedAction(action any, nonce uint64) (*UnsignedAction, error) {
+	srequest, err := api.BuildUserSignedEIP712Message(action)
+	if err != nil {
+		return nil, err
+	}
+	return newUnsignedAction(action, nonce, nil, api.signerAddress(), srequest)
+}
+
+func newUnsignedAction(action any, nonce uint64, vaultAddress *string, signer common.Address, srequest *SignRequest) (*UnsignedAction, error) {
+	typedData := SignRequestToEIP712TypedData(srequest)
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return &UnsignedAction{
+		Request: ExchangeRequest{
+			Action:       action,
+			Nonce:        nonce,
+			VaultAddress: vaultAddress,
+		},
+		TypedData: typedData,
+		Digest:    digest,
+		Signer:    signer,
+	}, nil
+}
+
+// SubmitSigned attaches an externally produced signature to a prepared request and sends it.
+// signature is 65 bytes [R || S || V] over unsigned.Digest, V being either 0/1 or 27/28,
+// made by the key of unsigned.Signer.
+// The digest is rebuilt from both unsigned.TypedData and unsigned.Request, so a request
+// changed after it was prepared is rejected instead of being sent with a signature of other data.
+// T is the response type of the action, e.g. OrderResponse for orders and cancels,
+// WithdrawResponse for withdrawals and DefaultExchangeResponse for the other actions.
+func SubmitSigned[T any](api *ExchangeAPI, unsigned *UnsignedAction, signature []byte) (*T, error) {
+	return SubmitSignedWithContext[T](context.Background(), api, unsigned, signature)
+}
+
+// SubmitSignedWithContext is the same as SubmitSigned but the request is bound to ctx.
+func SubmitSignedWithContext[T any](ctx context.Context, api *ExchangeAPI, unsigned *UnsignedAction, signature []byte) (*T, error) {
+	if api == nil {
+		return nil, APIError{Message: "API not set"}
+	}
+	if unsigned == nil {
+		return nil, APIError{Message: "Unsigned action not set"}
+	}
+	if time.Since(time.UnixMilli(int64(unsigned.Request.Nonce))) > NONCE_MAX_BEHIND {
+		return nil, ErrNonceWindow
+	}
+	digest, _, err := apitypes.TypedDataAndHash(unsigned.TypedData)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(digest, unsigned.Digest) {
+		return nil, fmt.Errorf("digest does not match the typed data")
+	}
+	// The signature is only valid for the request if the request hashes to the signed digest
+	rebuilt, err := requestDigest(unsigned.Request, api.IsMainnet())
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(rebuilt, unsigned.Digest) {
+		return nil, fmt.Errorf("digest does not match the request")
+	}
+	v, r, s, err := SignatureToVRS(signature)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := crypto.SigToPub(digest, append(append(r[:], s[:]...), v-27))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if unsigned.Signer == (common.Address{}) {
+		return nil, APIError{Message: "Signer of the unsigned action not set"}
+	}
+	if signer := crypto.PubkeyToAddress(*publicKey); signer != unsigned.Signer {
+		return nil, fmt.Errorf("signature is from %s, want %s", signer.Hex(), unsigned.Signer.Hex())
+	}
+	request := unsigned.Request
+	request.Signature = ToTypedSig(r, s, v)
+	// The signing key does not have to be set: the request is already signed
+	return sendUniversalRequest[T](ctx, api, request)
+}
@@ -0,0 +1,97 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSubmitSigned(t *testing.T) {
+	var body ExchangeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"status":"ok","response":{"type":"default"}}`))
+	}))
+	defer server.Close()
+
+	manager, _ := NewPKeyManager(testPrivateKey)
+	hot := NewExchangeAPI(true, WithBaseURL(server.URL))
+	hot.SetAccountAddress(manager.PublicAddressHex())
+	hot.SetVaultAddress("0x1719884eb866cb12b2287399b15f7db5e7d775ea")
+
+	prepared := map[string]func() (*UnsignedAction, error){
+		"withdraw": func() (*UnsignedAction, error) {
+			return hot.PrepareWithdraw(manager.PublicAddressHex(), 10)
+		},
+		"cancel": func() (*UnsignedAction, error) {
+			return hot.PrepareBulkCancelOrders([]CancelOidWire{{Asset: 1, Oid: 42}})
+		},
+	}
+	for name, prepare := range prepared {
+		t.Run(name, func(t *testing.T) {
+			unsigned, err := prepare()
+			if err != nil {
+				t.Fatalf("Prepare() error = %v", err)
+			}
+			data, err := json.Marshal(unsigned)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			// the cold signer only sees the JSON
+			var received UnsignedAction
+			if err := json.Unmarshal(data, &received); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			signature, err := crypto.Sign(received.Digest, manager.PrivateECDSA())
+			if err != nil {
+				t.Fatalf("crypto.Sign() error = %v", err)
+			}
+			if _, err := SubmitSigned[DefaultExchangeResponse](hot, &received, signature); err != nil {
+				t.Fatalf("SubmitSigned() error = %v", err)
+			}
+
+			want, _ := NewPrivateKeySigner(manager).SignTypedData(t.Context(), unsigned.TypedData)
+			v, r, s, _ := SignatureToVRS(want)
+			if body.Signature != ToTypedSig(r, s, v) {
+				t.Errorf("submitted signature = %v, want %v", body.Signature, ToTypedSig(r, s, v))
+			}
+			if body.Nonce != unsigned.Request.Nonce {
+				t.Errorf("submitted nonce = %v, want %v", body.Nonce, unsigned.Request.Nonce)
+			}
+		})
+	}
+
+	other, _ := NewPKeyManager("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	unsigned, _ := hot.PrepareWithdraw(manager.PublicAddressHex(), 10)
+	signature, _ := crypto.Sign(unsigned.Digest, other.PrivateECDSA())
+	if _, err := SubmitSigned[WithdrawResponse](hot, unsigned, signature); err == nil {
+		t.Errorf("SubmitSigned() signed by another key error = nil, want error")
+	}
+
+	unsigned, _ = hot.PrepareWithdraw(manager.PublicAddressHex(), 10)
+	unsigned.TypedData.Message["amount"] = "1000"
+	signature, _ = crypto.Sign(unsigned.Digest, manager.PrivateECDSA())
+	if _, err := SubmitSigned[WithdrawResponse](hot, unsigned, signature); err == nil {
+		t.Errorf("SubmitSigned() of modified typed data error = nil, want error")
+	}
+
+	unsigned, _ = hot.PrepareBulkCancelOrders([]CancelOidWire{{Asset: 1, Oid: 42}})
+	unsigned.Request.Action = CancelOidOrderAction{Type: "cancel", Cancels: []CancelOidWire{{Asset: 1, Oid: 43}}}
+	signature, _ = crypto.Sign(unsigned.Digest, manager.PrivateECDSA())
+	if _, err := SubmitSigned[OrderResponse](hot, unsigned, signature); err == nil {
+		t.Errorf("SubmitSigned() of modified request error = nil, want error")
+	}
+
+	unsigned, _ = hot.PrepareBulkCancelOrders([]CancelOidWire{{Asset: 1, Oid: 42}})
+	unsigned.Request.Nonce = uint64(time.Now().Add(-NONCE_MAX_BEHIND - time.Minute).UnixMilli())
+	signature, _ = crypto.Sign(unsigned.Digest, manager.PrivateECDSA())
+	if _, err := SubmitSigned[OrderResponse](hot, unsigned, signature); !errors.Is(err, ErrNonceWindow) {
+		t.Errorf("SubmitSigned() of an expired nonce error = %v, want %v", err, ErrNonceWindow)
+	}
+}
@@ -0,0 +1,134 @@
+package hyperliquid
+
+import "errors"
+
+// BatchResult pairs a request of a batch with its outcome.
+// At most one of Resting, Filled and Err is set; cancels that succeed have none of them.
+type BatchResult[R any] struct {
+	Index   int            // Position of the request in the batch
+	Request R              // Submitted request
+	Cloid   string         // Client order id of the request, if any
+	Resting *RestingStatus // Set when the order rests on the book
+	Filled  *FilledStatus  // Set when the order was filled immediately
+	Err     error          // *OrderError when the request was rejected
+}
+
+// Succeeded returns true if the request was accepted by the exchange.
+func (result BatchResult[R]) Succeeded() bool {
+	return result.Err == nil
+}
+
+// BatchResults are the outcomes of a batch, in the order of the requests.
+type BatchResults[R any] []BatchResult[R]
+
+// OrderResults are the outcomes of BulkOrders.
+type OrderResults = BatchResults[OrderRequest]
+
+// ModifyResults are the outcomes of BulkModifyOrders.
+type ModifyResults = BatchResults[ModifyOrderRequest]
+
+// CancelResults are the outcomes of BulkCancelOrders.
+type CancelResults = BatchResults[CancelOidWire]
+
+// CancelByCloidResults are the outcomes of BulkCancelOrdersByCloid.
+type CancelByCloidResults = BatchResults[CancelCloidRequest]
+
+// AllSucceeded returns true if every request of the batch was accepted.
+func (results BatchResults[R]) AllSucceeded() bool {
+	for _, result := range results {
+		if result.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the results of the rejected requests.
+func (results BatchResults[R]) Failed() BatchResults[R] {
+	var failed BatchResults[R]
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Succeeded returns the results of the accepted requests.
+func (results BatchResults[R]) Succeeded() BatchResults[R] {
+	var succeeded BatchResults[R]
+	for _, result := range results {
+		if result.Err == nil {
+			succeeded = append(succeeded, result)
+		}
+	}
+	return succeeded
+}
+
+// Err returns the errors of the rejected requests joined together, or nil if all succeeded.
+func (results BatchResults[R]) Err() error {
+	var errs []error
+	for _, result := range results {
+		errs = append(errs, result.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// PairOrderResults pairs the requests passed to BulkOrders with the statuses of its response.
+func PairOrderResults(requests []OrderRequest, response *OrderResponse) OrderResults {
+	return pairResults(requests, response, func(request OrderRequest) string { return string(request.Cloid) })
+}
+
+// PairModifyResults pairs the requests passed to BulkModifyOrders with the statuses of its response.
+func PairModifyResults(requests []ModifyOrderRequest, response *OrderResponse) ModifyResults {
+	return pairResults(requests, response, func(request ModifyOrderRequest) string { return string(request.Cloid) })
+}
+
+// PairCancelResults pairs the cancels passed to BulkCancelOrders with the statuses of its response.
+func PairCancelResults(cancels []CancelOidWire, response *OrderResponse) CancelResults {
+	return pairResults(cancels, response, func(CancelOidWire) string { return "" })
+}
+
+// PairCancelByCloidResults pairs the cancels passed to BulkCancelOrdersByCloid with the statuses of its response.
+func PairCancelByCloidResults(cancels []CancelCloidRequest, response *OrderResponse) CancelByCloidResults {
+	return pairResults(cancels, response, func(cancel CancelCloidRequest) string { return string(cancel.Cloid) })
+}
+
+func pairResults[R any](requests []R, response *OrderResponse, cloid func(R) string) BatchResults[R] {
+	var statuses []StatusResponse
+	if response != nil {
+		statuses = response.Response.Data.Statuses
+	}
+	results := make(BatchResults[R], len(requests))
+	for i, request := range requests {
+		result := BatchResult[R]{
+			Index:   i,
+			Request: request,
+			Cloid:   cloid(request),
+		}
+		if i >= len(statuses) {
+			result.Err = &OrderError{Index: i, Message: "no status returned for the request"}
+			results[i] = result
+			continue
+		}
+		status := statuses[i]
+		switch {
+		case status.Error != "":
+			result.Err = &OrderError{Index: i, Message: status.Error}
+		case status.Resting.OrderId != 0:
+			resting := status.Resting
+			result.Resting = &resting
+		case status.Filled.OrderId != 0:
+			filled := status.Filled
+			result.Filled = &filled
+		}
+		if result.Cloid == "" && result.Resting != nil {
+			result.Cloid = result.Resting.Cloid
+		}
+		if result.Cloid == "" && result.Filled != nil {
+			result.Cloid = result.Filled.Cloid
+		}
+		results[i] = result
+	}
+	return results
+}
@@ -0,0 +1,37 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestOrderResults_Pair(t *testing.T) {
+	var response OrderResponse
+	body := `{"status":"ok","response":{"type":"order","data":{"statuses":[
+		{"resting":{"oid":77738308}},
+		{"filled":{"totalSz":"0.02","avgPx":"1891.4","oid":77747314}},
+		{"error":"Order must have minimum value of $10. asset=1"}]}}}`
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	requests := []OrderRequest{
+		{Coin: "ETH", Cloid: "0x00000000000000000000000000000001"},
+		{Coin: "ETH", Cloid: "0x00000000000000000000000000000002"},
+		{Coin: "ETH", Cloid: "0x00000000000000000000000000000003"},
+	}
+	results := PairOrderResults(requests, &response)
+	if results.AllSucceeded() {
+		t.Errorf("AllSucceeded() = true, want false")
+	}
+	if results[0].Resting == nil || results[0].Resting.OrderId != 77738308 {
+		t.Errorf("results[0].Resting = %+v, want oid 77738308", results[0].Resting)
+	}
+	if results[1].Filled == nil || results[1].Filled.AvgPx != 1891.4 || results[1].Cloid != string(requests[1].Cloid) {
+		t.Errorf("results[1] = %+v, want fill at 1891.4", results[1])
+	}
+	failed := results.Failed()
+	if len(failed) != 1 || failed[0].Cloid != string(requests[2].Cloid) || !errors.Is(failed[0].Err, ErrMinTradeNotional) {
+		t.Errorf("Failed() = %+v, want the third order", failed)
+	}
+}
@@ -1,22 +1,30 @@
 package hyperliquid
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// PKeyManager holds a private key in memory.
+// Only the parsed key is kept, call Zero when it is no longer needed.
 type PKeyManager struct {
-	PrivateKeyStr string
-	privateKey    *ecdsa.PrivateKey
-	publicKey     *ecdsa.PublicKey
+	privateKey *ecdsa.PrivateKey
+	publicKey  *ecdsa.PublicKey
 }
 
 func (km *PKeyManager) PublicECDSA() *ecdsa.PublicKey {
 	return km.publicKey
 }
 
+// PrivateECDSA returns the private key, or nil after Zero.
 func (km *PKeyManager) PrivateECDSA() *ecdsa.PrivateKey {
 	return km.privateKey
 }
@@ -29,15 +37,87 @@ func (km *PKeyManager) PublicAddressHex() string {
 	return km.PublicAddress().Hex()
 }
 
+// Zero overwrites the private key in memory. The manager can not sign anymore,
+// its public key and address are still available.
+func (km *PKeyManager) Zero() {
+	if km.privateKey == nil {
+		return
+	}
+	b := km.privateKey.D.Bits()
+	for i := range b {
+		b[i] = 0
+	}
+	km.privateKey.D.SetInt64(0)
+	km.privateKey = nil
+}
+
 // NewPKeyManager creates a new PKeyManager instance from a private key string
 func NewPKeyManager(privateKey string) (*PKeyManager, error) {
-	privKey, err := crypto.HexToECDSA(privateKey)
+	privKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
 	if err != nil {
 		return nil, err
 	}
-	publicKey, ok := privKey.Public().(*ecdsa.PublicKey)
+	return newPKeyManager(privKey)
+}
+
+// NewPKeyManagerFromEnv creates a PKeyManager from the hex private key stored in the environment variable name.
+func NewPKeyManagerFromEnv(name string) (*PKeyManager, error) {
+	privateKey, ok := os.LookupEnv(name)
 	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+	return NewPKeyManager(strings.TrimSpace(privateKey))
+}
+
+// NewPKeyManagerFromReader creates a PKeyManager from a hex private key read from r,
+// e.g. a secret mounted as a file. The read buffers are zeroed.
+func NewPKeyManagerFromReader(r io.Reader) (*PKeyManager, error) {
+	data, err := io.ReadAll(io.LimitReader(r, 1024))
+	if err != nil {
 		return nil, err
 	}
-	return &PKeyManager{privateKey: privKey, publicKey: publicKey, PrivateKeyStr: privateKey}, nil
+	defer clear(data)
+	hexKey := bytes.TrimPrefix(bytes.TrimSpace(data), []byte("0x"))
+	if len(hexKey) != 2*32 {
+		return nil, fmt.Errorf("invalid private key length")
+	}
+	key := make([]byte, 32)
+	defer clear(key)
+	if _, err := hex.Decode(key, hexKey); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	privKey, err := crypto.ToECDSA(key)
+	if err != nil {
+		return nil, err
+	}
+	return newPKeyManager(privKey)
+}
+
+// NewPKeyManagerFromFD creates a PKeyManager from a hex private key read from the file descriptor fd,
+// e.g. a pipe passed by a secret manager. The descriptor is closed.
+func NewPKeyManagerFromFD(fd uintptr) (*PKeyManager, error) {
+	file := os.NewFile(fd, "private-key")
+	if file == nil {
+		return nil, fmt.Errorf("invalid file descriptor %d", fd)
+	}
+	defer file.Close()
+	return NewPKeyManagerFromReader(file)
+}
+
+// GeneratePKeyManager creates a PKeyManager with a new random private key.
+// Use EncryptKeystore to store it.
+func GeneratePKeyManager() (*PKeyManager, error) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	return newPKeyManager(privKey)
+}
+
+func newPKeyManager(privKey *ecdsa.PrivateKey) (*PKeyManager, error) {
+	publicKey, ok := privKey.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return &PKeyManager{privateKey: privKey, publicKey: publicKey}, nil
 }
@@ -0,0 +1,243 @@
+package hyperliquid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitPolicy defines what the RateLimiter does when the weight budget is exhausted.
+type RateLimitPolicy int
+
+const (
+	RateLimitWait     RateLimitPolicy = iota // Block until enough weight is available
+	RateLimitFailFast                        // Return a RateLimitError immediately
+)
+
+// RateLimiter is a client-side token bucket modeled on the Hyperliquid limits.
+//
+// The IP limit is a weight budget that refills continuously, every request consumes
+// the weight of its type (see InfoRequestWeight and ExchangeActionWeight).
+// The address limit is a number of actions, it is only enforced after a call to
+// SyncUserRateLimits and then counts every order or cancel of a batch as one action.
+// Cancels are allowed beyond the cap, up to min(cap + ADDRESS_CANCEL_EXTRA, 2 * cap) actions.
+// The address budget never blocks: once it is spent the actions fail with a *RateLimitError.
+// Hyperliquid raises the cap with the traded volume (1 action per USDC traded), which the limiter
+// can not see, so run ExchangeAPI.RunRateLimitSync to pick up the new cap once it is synced.
+//
+// A RateLimiter is safe for concurrent use and can be shared between clients
+// that use the same IP with WithRateLimiter.
+type RateLimiter struct {
+	mu           sync.Mutex
+	policy       RateLimitPolicy
+	capacity     float64
+	tokens       float64
+	refillPerSec float64
+	lastRefill   time.Time
+
+	addressSynced bool
+	addressUsed   int
+	addressCap    int
+}
+
+// NewRateLimiter returns a limiter allowing weightPerMinute weight per minute,
+// use REST_WEIGHT_LIMIT_PER_MINUTE for the default Hyperliquid limit.
+func NewRateLimiter(weightPerMinute int, policy RateLimitPolicy) *RateLimiter {
+	capacity := float64(max(weightPerMinute, 1))
+	return &RateLimiter{
+		policy:       policy,
+		capacity:     capacity,
+		tokens:       capacity,
+		refillPerSec: capacity / 60,
+		lastRefill:   time.Now(),
+	}
+}
+
+// WithRateLimiter throttles all requests of the client with the limiter.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(client *Client) {
+		client.rateLimiter = limiter
+	}
+}
+
+// InfoRequestWeight returns the weight of an /info request of the given type.
+func InfoRequestWeight(requestType string) int {
+	switch requestType {
+	case "l2Book", "allMids", "clearinghouseState", "orderStatus", "spotClearinghouseState", "exchangeStatus":
+		return 2
+	case "userRole":
+		return 60
+	}
+	return 20
+}
+
+// ExchangeActionWeight returns the weight of an /exchange action
+// containing batchLength orders, cancels or modifies.
+func ExchangeActionWeight(batchLength int) int {
+	return 1 + batchLength/EXCHANGE_BATCH_WEIGHT_STEP
+}
+
+// Wait consumes weight from the budget.
+// Depending on the policy it blocks until the weight is available or returns a *RateLimitError.
+func (rl *RateLimiter) Wait(ctx context.Context, weight int) error {
+	need := min(float64(weight), rl.capacity)
+	for {
+		rl.mu.Lock()
+		rl.refill()
+		if rl.tokens >= need {
+			rl.tokens -= need
+			rl.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((need - rl.tokens) / rl.refillPerSec * float64(time.Second))
+		rl.mu.Unlock()
+
+		if rl.policy == RateLimitFailFast {
+			return &RateLimitError{
+				Reason:     fmt.Sprintf("weight %d exceeds the available budget", weight),
+				RetryAfter: delay,
+			}
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// SyncUserRateLimits updates the address budget with the values
+// returned by InfoAPI.GetUserRateLimits.
+func (rl *RateLimiter) SyncUserRateLimits(limits *RatesLimits) {
+	if limits == nil {
+		return
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.addressSynced = true
+	rl.addressUsed = limits.NRequestsUsed
+	rl.addressCap = limits.NRequestsCap
+}
+
+// AddressRequestsRemaining returns the number of actions the address can still send,
+// not counting the extra cancel allowance, and false if the budget was never synced.
+func (rl *RateLimiter) AddressRequestsRemaining() (int, bool) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return max(rl.addressCap-rl.addressUsed, 0), rl.addressSynced
+}
+
+// takeActions consumes n actions from the address budget, cancels may use the extra cancel allowance.
+// The address budget only grows with the traded volume, so waiting does not help here.
+func (rl *RateLimiter) takeActions(n int, isCancel bool) error {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if !rl.addressSynced {
+		return nil
+	}
+	limit := rl.addressCap
+	if isCancel {
+		limit = min(rl.addressCap+ADDRESS_CANCEL_EXTRA, 2*rl.addressCap)
+	}
+	if remaining := limit - rl.addressUsed; remaining < n {
+		return &RateLimitError{Reason: fmt.Sprintf("%d actions requested but only %d left for the address", n, max(remaining, 0))}
+	}
+	rl.addressUsed += n
+	return nil
+}
+
+// refund gives back weight consumed by a request that is not sent.
+func (rl *RateLimiter) refund(weight int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.refill()
+	rl.tokens = min(rl.capacity, rl.tokens+min(float64(weight), rl.capacity))
+}
+
+func (rl *RateLimiter) refill() {
+	now := time.Now()
+	elapsed := now.Sub(rl.lastRefill).Seconds()
+	rl.tokens = min(rl.capacity, rl.tokens+elapsed*rl.refillPerSec)
+	rl.lastRefill = now
+}
+
+// acquire applies the limits to an encoded request for the given endpoint.
+// Every attempt consumes weight, but the actions of an exchange request are only counted
+// against the address budget when countActions is set, i.e. once per request and not per retry.
+func (rl *RateLimiter) acquire(ctx context.Context, endpoint string, payload []byte, countActions bool) error {
+	if endpoint != "exchange" {
+		var request struct {
+			Type string `json:"type"`
+		}
+		_ = json.Unmarshal(payload, &request)
+		return rl.Wait(ctx, InfoRequestWeight(request.Type))
+	}
+	batchLength, isCancel := exchangeBatch(payload)
+	weight := ExchangeActionWeight(batchLength)
+	if err := rl.Wait(ctx, weight); err != nil {
+		return err
+	}
+	if !countActions {
+		return nil
+	}
+	if err := rl.takeActions(max(batchLength, 1), isCancel); err != nil {
+		rl.refund(weight)
+		return err
+	}
+	return nil
+}
+
+// exchangeBatch returns the number of orders, cancels or modifies of an encoded ExchangeRequest
+// and whether it is a cancel.
+func exchangeBatch(payload []byte) (int, bool) {
+	var request struct {
+		Action struct {
+			Type     string            `json:"type"`
+			Orders   []json.RawMessage `json:"orders"`
+			Cancels  []json.RawMessage `json:"cancels"`
+			Modifies []json.RawMessage `json:"modifies"`
+		} `json:"action"`
+	}
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return 0, false
+	}
+	isCancel := request.Action.Type == "cancel" || request.Action.Type == "cancelByCloid"
+	return len(request.Action.Orders) + len(request.Action.Cancels) + len(request.Action.Modifies), isCancel
+}
+
+// RateLimiter returns the rate limiter of the client or nil if none is set.
+func (client *Client) RateLimiter() *RateLimiter {
+	return client.rateLimiter
+}
+
+// SyncRateLimits fetches the address limits with GetUserRateLimits and updates the rate limiter of the client with them.
+// The limits are the ones of the address the actions are sent for: the vault or subaccount if one is set, otherwise the account.
+func (api *ExchangeAPI) SyncRateLimits(ctx context.Context) error {
+	if api.rateLimiter == nil {
+		return APIError{Message: "Rate limiter not set"}
+	}
+	limits, err := api.infoAPI.GetUserRateLimitsWithContext(ctx, api.tradingAddress())
+	if err != nil {
+		return err
+	}
+	api.rateLimiter.SyncUserRateLimits(limits)
+	return nil
+}
+
+// RunRateLimitSync calls SyncRateLimits every interval until ctx is done.
+// It is required once the address budget is synced: between syncs the budget only decreases.
+// Errors are reported to onError, which can be nil. Run it in its own goroutine.
+func (api *ExchangeAPI) RunRateLimitSync(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := api.SyncRateLimits(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
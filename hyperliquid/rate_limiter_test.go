@@ -0,0 +1,80 @@
+package hyperliquid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestRateLimiter_Weights(t *testing.T) {
+	limiter := NewRateLimiter(REST_WEIGHT_LIMIT_PER_MINUTE, RateLimitFailFast)
+	ctx := context.Background()
+
+	// 60 clearinghouseState requests weigh 120, then a meta request weighs 20
+	for i := 0; i < 60; i++ {
+		if err := limiter.acquire(ctx, "info", []byte(`{"type":"clearinghouseState"}`), true); err != nil {
+			t.Fatalf("acquire() error = %v", err)
+		}
+	}
+	if limiter.tokens > REST_WEIGHT_LIMIT_PER_MINUTE-120+1 {
+		t.Errorf("tokens = %v, want about %v", limiter.tokens, REST_WEIGHT_LIMIT_PER_MINUTE-120)
+	}
+
+	orders := make([]OrderWire, 80)
+	payload, _ := json.Marshal(ExchangeRequest{Action: OrderWiresToOrderAction(orders, GroupingNa)})
+	if n, isCancel := exchangeBatch(payload); n != 80 || isCancel {
+		t.Errorf("exchangeBatch() = %v, %v, want %v, false", n, isCancel, 80)
+	}
+	if w := ExchangeActionWeight(80); w != 3 {
+		t.Errorf("ExchangeActionWeight(80) = %v, want %v", w, 3)
+	}
+
+	limiter.SyncUserRateLimits(&RatesLimits{NRequestsUsed: 9900, NRequestsCap: 10000})
+	if err := limiter.acquire(ctx, "exchange", payload, true); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	// retries consume weight but not actions
+	tokens := limiter.tokens
+	if err := limiter.acquire(ctx, "exchange", payload, false); err != nil {
+		t.Fatalf("acquire() retry error = %v", err)
+	}
+	if remaining, _ := limiter.AddressRequestsRemaining(); remaining != 20 || limiter.tokens > tokens-2 {
+		t.Errorf("after retry remaining = %v, tokens = %v, want 20 and about %v", remaining, limiter.tokens, tokens-3)
+	}
+	// a rejected request gives its weight back
+	tokens = limiter.tokens
+	if err := limiter.acquire(ctx, "exchange", payload, true); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("acquire() error = %v, want %v", err, ErrRateLimited)
+	}
+	if remaining, _ := limiter.AddressRequestsRemaining(); remaining != 20 || limiter.tokens < tokens {
+		t.Errorf("after rejection remaining = %v, tokens = %v, want 20 and %v", remaining, limiter.tokens, tokens)
+	}
+	// cancels may go past the cap
+	cancels := make([]CancelOidWire, 80)
+	cancelPayload, _ := json.Marshal(ExchangeRequest{Action: CancelOidOrderAction{Type: "cancel", Cancels: cancels}})
+	if err := limiter.acquire(ctx, "exchange", cancelPayload, true); err != nil {
+		t.Errorf("acquire() of cancels past the cap error = %v", err)
+	}
+	if remaining, _ := limiter.AddressRequestsRemaining(); remaining != 0 {
+		t.Errorf("after cancels remaining = %v, want 0", remaining)
+	}
+
+	// actions are not taken when waiting for weight fails
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	waiting := NewRateLimiter(REST_WEIGHT_LIMIT_PER_MINUTE, RateLimitWait)
+	waiting.SyncUserRateLimits(&RatesLimits{NRequestsUsed: 0, NRequestsCap: 100})
+	waiting.tokens = 0
+	if err := waiting.acquire(cancelled, "exchange", payload, true); !errors.Is(err, context.Canceled) {
+		t.Errorf("acquire() error = %v, want %v", err, context.Canceled)
+	}
+	if remaining, _ := waiting.AddressRequestsRemaining(); remaining != 100 {
+		t.Errorf("remaining = %v, want 100", remaining)
+	}
+
+	limiter.tokens = 0
+	if err := limiter.Wait(ctx, 20); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Wait() error = %v, want %v", err, ErrRateLimited)
+	}
+}
@@ -0,0 +1,160 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// RemoteSignRequest is the body that RemoteSigner posts to the signing service.
+// TypedData is set when the service is asked to sign an EIP-712 message,
+// so it can check what it signs. Digest is always set.
+type RemoteSignRequest struct {
+	Address   common.Address      `json:"address"`
+	Digest    hexutil.Bytes       `json:"digest"`
+	TypedData *apitypes.TypedData `json:"typedData,omitempty"`
+}
+
+// RemoteSignResponse is the answer of the signing service.
+// Signature is 65 bytes [R || S || V].
+type RemoteSignResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// RemoteSigner is a Signer that delegates signing to an HTTP service,
+// so the private key never enters the process.
+// It posts a RemoteSignRequest to the URL and expects a RemoteSignResponse.
+// The returned signature is checked against the address.
+// NewRemoteSignerHandler implements the service on top of any other Signer.
+type RemoteSigner struct {
+	url        string
+	address    common.Address
+	httpClient *http.Client
+}
+
+// NewRemoteSigner returns a RemoteSigner for the key of address served at url.
+// httpClient may be nil to use http.DefaultClient.
+func NewRemoteSigner(url string, address common.Address, httpClient *http.Client) *RemoteSigner {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &RemoteSigner{
+		url:        url,
+		address:    address,
+		httpClient: httpClient,
+	}
+}
+
+func (signer *RemoteSigner) Address() common.Address {
+	return signer.address
+}
+
+func (signer *RemoteSigner) SignTypedData(ctx context.Context, data apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return nil, err
+	}
+	return signer.sign(ctx, RemoteSignRequest{Address: signer.address, Digest: digest, TypedData: &data})
+}
+
+func (signer *RemoteSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	return signer.sign(ctx, RemoteSignRequest{Address: signer.address, Digest: digest})
+}
+
+func (signer *RemoteSigner) sign(ctx context.Context, signRequest RemoteSignRequest) ([]byte, error) {
+	payload, err := json.Marshal(signRequest)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, "POST", signer.url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := signer.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return nil, &HTTPError{StatusCode: response.StatusCode, Body: data}
+	}
+	var result RemoteSignResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, &DecodeError{Body: data, Err: err}
+	}
+	if err := verifyDigestSignature(signRequest.Digest, result.Signature, signer.address); err != nil {
+		return nil, err
+	}
+	return result.Signature, nil
+}
+
+// verifyDigestSignature checks that the signature of digest recovers to address.
+func verifyDigestSignature(digest []byte, signature []byte, address common.Address) error {
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+	sig := bytes.Clone(signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return err
+	}
+	if recovered := crypto.PubkeyToAddress(*publicKey); recovered != address {
+		return fmt.Errorf("signature recovers to %s, want %s", recovered.Hex(), address.Hex())
+	}
+	return nil
+}
+
+// NewRemoteSignerHandler serves the RemoteSigner protocol with signer.
+// It can stand in for a signing service in tests or run as a minimal signing sidecar.
+// Requests for another address are rejected, as are typed data that do not hash to the digest.
+func NewRemoteSignerHandler(signer Signer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var signRequest RemoteSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&signRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if signRequest.Address != signer.Address() {
+			http.Error(w, "unknown address", http.StatusNotFound)
+			return
+		}
+		var signature []byte
+		var err error
+		if signRequest.TypedData != nil {
+			digest, _, hashErr := apitypes.TypedDataAndHash(*signRequest.TypedData)
+			if hashErr != nil || !bytes.Equal(digest, signRequest.Digest) {
+				http.Error(w, "typed data does not match the digest", http.StatusBadRequest)
+				return
+			}
+			signature, err = signer.SignTypedData(r.Context(), *signRequest.TypedData)
+		} else {
+			signature, err = signer.SignDigest(r.Context(), signRequest.Digest)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(RemoteSignResponse{Signature: signature})
+	})
+}
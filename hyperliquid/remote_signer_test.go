@@ -0,0 +1,61 @@
+package hyperliquid
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	manager, err := NewPKeyManager(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewPKeyManager() error = %v", err)
+	}
+	server := httptest.NewServer(NewRemoteSignerHandler(NewPrivateKeySigner(manager)))
+	defer server.Close()
+
+	local := NewExchangeAPI(true)
+	local.SetKeyManager(manager)
+	remote := NewExchangeAPI(true)
+	remote.SetSigner(NewRemoteSigner(server.URL, manager.PublicAddress(), nil))
+	if remote.KeyManager() != nil {
+		t.Errorf("KeyManager() = %v, want nil", remote.KeyManager())
+	}
+
+	action := UpdateLeverageAction{Type: "updateLeverage", Asset: 1, IsCross: true, Leverage: 10}
+	wantV, wantR, wantS, err := local.SignL1Action(action, 1700000000000)
+	if err != nil {
+		t.Fatalf("SignL1Action() error = %v", err)
+	}
+	v, r, s, err := remote.SignL1Action(action, 1700000000000)
+	if err != nil {
+		t.Fatalf("remote SignL1Action() error = %v", err)
+	}
+	if v != wantV || r != wantR || s != wantS {
+		t.Errorf("remote SignL1Action() signature differs from the local signer")
+	}
+
+	withdraw := WithdrawAction{
+		Type:             "withdraw3",
+		Destination:      manager.PublicAddressHex(),
+		Amount:           "10",
+		Time:             1700000000000,
+		HyperliquidChain: "Mainnet",
+		SignatureChainID: "0xa4b1",
+	}
+	wantV, wantR, wantS, _ = local.SignWithdrawAction(withdraw)
+	v, r, s, err = remote.SignWithdrawAction(withdraw)
+	if err != nil {
+		t.Fatalf("remote SignWithdrawAction() error = %v", err)
+	}
+	if v != wantV || r != wantR || s != wantS {
+		t.Errorf("remote SignWithdrawAction() signature differs from the local signer")
+	}
+
+	other := NewExchangeAPI(true)
+	other.SetSigner(NewRemoteSigner(server.URL, common.HexToAddress("0x1719884eb866cb12b2287399b15f7db5e7d775ea"), nil))
+	if _, _, _, err := other.SignL1Action(action, 1700000000000); err == nil {
+		t.Errorf("SignL1Action() with an unknown address error = nil, want error")
+	}
+}
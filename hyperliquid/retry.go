@@ -0,0 +1,134 @@
+package hyperliquid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried.
+//
+// A request is retried on transport errors and on the RetryableStatusCodes.
+// Requests to /info are read-only and always retried.
+// Requests to /exchange are only retried when the payload is a signed ExchangeRequest:
+// the exact same bytes (and therefore the same nonce) are sent again, so the exchange
+// rejects the duplicate if the first attempt was processed.
+// When an attempt got no response (or a server error) and the retry is rejected because of its nonce,
+// the action was most likely applied by that attempt: the RetryError then matches ErrPossiblyExecuted.
+// Reconcile with the open orders and fills (e.g. by cloid) before sending the action again.
+type RetryPolicy struct {
+	MaxAttempts          int           // Total number of attempts including the first one
+	InitialBackoff       time.Duration // Delay before the first retry
+	MaxBackoff           time.Duration // Upper bound of the delay between two attempts
+	Multiplier           float64       // Growth factor of the delay after each attempt
+	Jitter               float64       // Random part of the delay as a fraction (0.2 = +/-20%)
+	RetryableStatusCodes []int         // HTTP status codes that are retried
+}
+
+// DefaultRetryPolicy returns a policy with 4 attempts and exponential backoff
+// starting at 200ms, retrying rate limiting (429) and server errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          4,
+		InitialBackoff:       200 * time.Millisecond,
+		MaxBackoff:           5 * time.Second,
+		Multiplier:           2,
+		Jitter:               0.2,
+		RetryableStatusCodes: []int{429, 500, 502, 503, 504},
+	}
+}
+
+// WithRetryPolicy enables retries of failed requests. See RetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(client *Client) {
+		client.retryPolicy = &policy
+	}
+}
+
+// ErrPossiblyExecuted is matched by a RetryError when a retried exchange request is rejected because of its nonce
+// after an attempt whose response was lost, see RetryPolicy.
+var ErrPossiblyExecuted = errors.New("action may have been executed by an earlier attempt")
+
+// RetryError is returned when a request still failed after being retried.
+// Err is the error of the last attempt.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts: %s", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (policy *RetryPolicy) isRetryableStatus(statusCode int) bool {
+	return policy != nil && slices.Contains(policy.RetryableStatusCodes, statusCode)
+}
+
+// backoff returns the delay before the next attempt, attempt starts at 1.
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if policy.MaxBackoff > 0 {
+		delay = math.Min(delay, float64(policy.MaxBackoff))
+	}
+	if policy.Jitter > 0 {
+		delay *= 1 + policy.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(delay)
+}
+
+// isRetrySafe reports whether sending the payload twice cannot execute an action twice.
+func isRetrySafe(endpoint string, payload any) bool {
+	if endpoint != "exchange" {
+		return true
+	}
+	switch request := payload.(type) {
+	case ExchangeRequest:
+		return request.Nonce != 0
+	case *ExchangeRequest:
+		return request != nil && request.Nonce != 0
+	}
+	return false
+}
+
+// possiblyExecutedError returns an error matching ErrPossiblyExecuted and the ExchangeError
+// if the response of a retried exchange request is a nonce rejection, nil otherwise.
+func possiblyExecutedError(response []byte) error {
+	var result struct {
+		Status   string          `json:"status"`
+		Response json.RawMessage `json:"response"`
+	}
+	if json.Unmarshal(response, &result) != nil || result.Status != "err" {
+		return nil
+	}
+	exchangeErr := newExchangeError(result.Response)
+	if !strings.Contains(strings.ToLower(exchangeErr.Message), "nonce") {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrPossiblyExecuted, exchangeErr)
+}
+
+// sleepContext waits for the duration or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
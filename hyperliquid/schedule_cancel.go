@@ -0,0 +1,40 @@
+package hyperliquid
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RunScheduleCancelHeartbeat keeps a dead man's switch armed: every interval it schedules
+// the cancel of all open orders at now + timeout with ScheduleCancel, until ctx is done.
+// If the process dies or loses connectivity for longer than timeout, the orders are canceled.
+// Failed refreshes are reported to onError, which can be nil, and retried on the next tick,
+// so timeout should be a few intervals long.
+// The last scheduled cancel is left in place when ctx is done, call ScheduleCancel(nil) to remove it.
+// Run it in its own goroutine. It returns an error only if the arguments are invalid.
+func (api *ExchangeAPI) RunScheduleCancelHeartbeat(ctx context.Context, interval time.Duration, timeout time.Duration, onError func(error)) error {
+	if timeout < SCHEDULE_CANCEL_MIN_DELAY {
+		return fmt.Errorf("schedule cancel timeout must be at least %s", SCHEDULE_CANCEL_MIN_DELAY)
+	}
+	if interval <= 0 || interval >= timeout {
+		return fmt.Errorf("schedule cancel interval must be positive and shorter than the timeout")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		deadline := time.Now().Add(timeout).UnixMilli()
+		response, err := api.ScheduleCancelWithContext(ctx, &deadline)
+		if err == nil && response.Status != "ok" {
+			err = fmt.Errorf("unexpected schedule cancel status: %s", response.Status)
+		}
+		if err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package hyperliquid
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExchangeAPI_RunScheduleCancelHeartbeat(t *testing.T) {
+	var mu sync.Mutex
+	var times []any
+	calls := 0
+	exchangeAPI, _ := newTestExchangeAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Action map[string]any `json:"action"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		defer mu.Unlock()
+		times = append(times, body.Action["time"])
+		calls++
+		if calls == 1 {
+			w.Write([]byte(`{"status":"err","response":"Too many scheduled cancels"}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok","response":{"type":"default"}}`))
+	}), nil)
+	if err := exchangeAPI.RunScheduleCancelHeartbeat(context.Background(), time.Second, time.Second, nil); err == nil {
+		t.Errorf("RunScheduleCancelHeartbeat() with a too short timeout error = nil, want error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var errs []error
+	done := make(chan error)
+	go func() {
+		done <- exchangeAPI.RunScheduleCancelHeartbeat(ctx, 10*time.Millisecond, 10*time.Second, func(err error) {
+			errs = append(errs, err)
+		})
+	}()
+	for {
+		mu.Lock()
+		n := len(times)
+		mu.Unlock()
+		if n >= 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("RunScheduleCancelHeartbeat() error = %v", err)
+	}
+	if len(errs) != 1 {
+		t.Errorf("onError called %d times, want 1", len(errs))
+	}
+	deadline := time.Now().Add(10 * time.Second).UnixMilli()
+	if last := times[len(times)-1].(float64); last > float64(deadline) || last < float64(deadline-1000) {
+		t.Errorf("scheduled time = %v, want about %v", last, deadline)
+	}
+
+	// nil removes the scheduled cancel, the field must not be sent
+	times = nil
+	if _, err := exchangeAPI.ScheduleCancel(nil); err != nil {
+		t.Fatalf("ScheduleCancel(nil) error = %v", err)
+	}
+	if times[0] != nil {
+		t.Errorf("ScheduleCancel(nil) sent time = %v", times[0])
+	}
+}
@@ -1,11 +1,12 @@
 package hyperliquid
 
 import (
+	"context"
 	"encoding/binary"
 	"fmt"
-	"log"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
@@ -13,16 +14,22 @@ import (
 )
 
 // SignRequest is the implementation of EIP-712 typed data
+// SignatureChainID is the chain id of user-signed actions, the default of the network is used if it is empty.
 type SignRequest struct {
-	PrimaryType string
-	DType       []apitypes.Type
-	DTypeMsg    map[string]any
-	IsMainNet   bool
-	DomainName  string
+	PrimaryType      string
+	DType            []apitypes.Type
+	DTypeMsg         map[string]any
+	IsMainNet        bool
+	DomainName       string
+	SignatureChainID string
 }
 
 func (request *SignRequest) getChainId() *math.HexOrDecimal256 {
 	if request.DomainName == "HyperliquidSignTransaction" {
+		var chainId math.HexOrDecimal256
+		if request.SignatureChainID != "" && chainId.UnmarshalText([]byte(request.SignatureChainID)) == nil {
+			return &chainId
+		}
 		if request.IsMainNet {
 			return math.NewHexOrDecimal256(int64(ARBITRUM_CHAIN_ID))
 		}
@@ -53,31 +60,61 @@ func (request *SignRequest) GetDomain() apitypes.TypedDataDomain {
 	}
 }
 
-type Signer struct {
+// Signer signs the EIP-712 messages of Hyperliquid actions.
+// Implement it to keep the private key out of the process memory,
+// e.g. in a KMS, an HSM or a remote signing service (see RemoteSigner).
+// Signatures are 65 bytes [R || S || V], V being either 0/1 or 27/28.
+type Signer interface {
+	// Address returns the address of the signing key.
+	Address() common.Address
+	// SignTypedData signs EIP-712 typed data.
+	SignTypedData(ctx context.Context, data apitypes.TypedData) ([]byte, error)
+	// SignDigest signs a 32 bytes hash.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// PrivateKeySigner is the Signer backed by a private key held in memory.
+type PrivateKeySigner struct {
 	manager *PKeyManager
 }
 
-func NewSigner(manager *PKeyManager) Signer {
-	return Signer{
+func NewPrivateKeySigner(manager *PKeyManager) *PrivateKeySigner {
+	return &PrivateKeySigner{
 		manager: manager,
 	}
 }
 
-func (signer *Signer) Sign(request *SignRequest) (byte, [32]byte, [32]byte, error) {
-	return signer.signInternal(SignRequestToEIP712TypedData(request))
+// NewSigner returns the Signer backed by the private key of manager.
+//
+// Deprecated: use NewPrivateKeySigner.
+func NewSigner(manager *PKeyManager) Signer {
+	return NewPrivateKeySigner(manager)
+}
+
+func (signer *PrivateKeySigner) Address() common.Address {
+	return signer.manager.PublicAddress()
 }
 
-// signInternal signs the typed data and returns the signature in VRS format
-func (signer *Signer) signInternal(message apitypes.TypedData) (byte, [32]byte, [32]byte, error) {
-	pkey := signer.manager.PrivateECDSA()
-	bytes, _, err := apitypes.TypedDataAndHash(message)
+func (signer *PrivateKeySigner) SignTypedData(ctx context.Context, data apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(data)
 	if err != nil {
-		log.Printf("Error hashing typed data: %s", err)
-		return 0, [32]byte{}, [32]byte{}, err
+		return nil, err
 	}
-	signature, err := crypto.Sign(bytes, pkey)
+	return signer.SignDigest(ctx, digest)
+}
+
+func (signer *PrivateKeySigner) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	privateKey := signer.manager.PrivateECDSA()
+	if privateKey == nil {
+		return nil, fmt.Errorf("private key is zeroed")
+	}
+	return crypto.Sign(digest, privateKey)
+}
+
+// signRequest signs the typed data of the request and returns the signature in VRS format
+func signRequest(ctx context.Context, signer Signer, request *SignRequest) (byte, [32]byte, [32]byte, error) {
+	signature, err := signer.SignTypedData(ctx, SignRequestToEIP712TypedData(request))
 	if err != nil {
-		log.Printf("Error signing typed data: %s", err)
 		return 0, [32]byte{}, [32]byte{}, err
 	}
 	return SignatureToVRS(signature)
@@ -92,11 +129,18 @@ func SignRequestToEIP712TypedData(request *SignRequest) apitypes.TypedData {
 	}
 }
 
+// SignatureToVRS splits a 65 bytes [R || S || V] signature. V is returned as 27 or 28.
 func SignatureToVRS(sig []byte) (byte, [32]byte, [32]byte, error) {
 	var v byte
 	var r [32]byte
 	var s [32]byte
-	v = sig[64] + 27
+	if len(sig) != crypto.SignatureLength {
+		return 0, r, s, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	v = sig[64]
+	if v < 27 {
+		v += 27
+	}
 	copy(r[:], sig[:32])
 	copy(s[:], sig[32:64])
 	return v, r, s, nil
@@ -135,6 +179,6 @@ func buildMessage(hash []byte, isMainnet bool) apitypes.TypedDataMessage {
 	source := getNetSource(isMainnet)
 	return apitypes.TypedDataMessage{
 		"source":       source,
-		"connectionId": hash,
+		"connectionId": hexutil.Bytes(hash),
 	}
 }
@@ -0,0 +1,152 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrSignerMismatch is returned by VerifySigner when the request is signed by an unexpected address.
+var ErrSignerMismatch = errors.New("request is not signed by an expected address")
+
+// actionKind describes how an exchange action is signed.
+type actionKind struct {
+	goType     reflect.Type
+	userSigned bool
+}
+
+// actionKinds maps the type of the exchange actions to their Go type,
+// so actions decoded from JSON are hashed with the field order they were signed with.
+var actionKinds = map[string]actionKind{
+	"order":                  {goType: reflect.TypeOf(PlaceOrderAction{})},
+	"cancel":                 {goType: reflect.TypeOf(CancelOidOrderAction{})},
+	"cancelByCloid":          {goType: reflect.TypeOf(CancelCloidOrderAction{})},
+	"batchModify":            {goType: reflect.TypeOf(ModifyOrderAction{})},
+	"updateLeverage":         {goType: reflect.TypeOf(UpdateLeverageAction{})},
+	"updateIsolatedMargin":   {goType: reflect.TypeOf(UpdateIsolatedMarginAction{})},
+	"twapOrder":              {goType: reflect.TypeOf(TwapOrderAction{})},
+	"twapCancel":             {goType: reflect.TypeOf(TwapCancelAction{})},
+	"scheduleCancel":         {goType: reflect.TypeOf(ScheduleCancelAction{})},
+	"withdraw3":              {goType: reflect.TypeOf(WithdrawAction{}), userSigned: true},
+	"usdClassTransfer":       {goType: reflect.TypeOf(UsdClassTransferAction{}), userSigned: true},
+	"createSubAccount":       {goType: reflect.TypeOf(CreateSubAccountAction{})},
+	"subAccountModify":       {goType: reflect.TypeOf(SubAccountModifyAction{})},
+	"subAccountTransfer":     {goType: reflect.TypeOf(SubAccountTransferAction{})},
+	"subAccountSpotTransfer": {goType: reflect.TypeOf(SubAccountSpotTransferAction{})},
+	"vaultTransfer":          {goType: reflect.TypeOf(VaultTransferAction{})},
+	"usdSend":                {goType: reflect.TypeOf(UsdSendAction{}), userSigned: true},
+	"spotSend":               {goType: reflect.TypeOf(SpotSendAction{}), userSigned: true},
+	"approveBuilderFee":      {goType: reflect.TypeOf(ApproveBuilderFeeAction{}), userSigned: true},
+	"approveAgent":           {goType: reflect.TypeOf(ApproveAgentAction{}), userSigned: true},
+}
+
+// decodeAction converts an action, either a typed struct or its JSON form
+// (map[string]any, json.RawMessage...), to its typed struct.
+func decodeAction(action any) (any, actionKind, error) {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return nil, actionKind{}, err
+	}
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, actionKind{}, fmt.Errorf("invalid action: %w", err)
+	}
+	kind, ok := actionKinds[header.Type]
+	if !ok {
+		return nil, actionKind{}, fmt.Errorf("unsupported action type: %q", header.Type)
+	}
+	typed := reflect.New(kind.goType)
+	if err := json.Unmarshal(data, typed.Interface()); err != nil {
+		return nil, actionKind{}, fmt.Errorf("invalid %s action: %w", header.Type, err)
+	}
+	return typed.Elem().Interface(), kind, nil
+}
+
+// RecoverSigner returns the address that signed the exchange request on the given network.
+// User-signed actions carry their network (hyperliquidChain and signatureChainId), isMainnet only applies to L1 actions.
+// It rebuilds the L1 action hash (including the nonce and the vault address)
+// or the typed data of a user-signed action, so the action may be a typed struct
+// or the JSON object received by a gateway.
+// The address is the agent, not the master account, if the request is signed by an agent.
+func RecoverSigner(request ExchangeRequest, isMainnet bool) (common.Address, error) {
+	digest, err := requestDigest(request, isMainnet)
+	if err != nil {
+		return common.Address{}, err
+	}
+	signature, err := rsvToSignature(request.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	publicKey, err := crypto.SigToPub(digest, signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// requestDigest rebuilds the EIP-712 digest the exchange request is signed over.
+func requestDigest(request ExchangeRequest, isMainnet bool) ([]byte, error) {
+	action, kind, err := decodeAction(request.Action)
+	if err != nil {
+		return nil, err
+	}
+	var srequest *SignRequest
+	if kind.userSigned {
+		srequest, err = buildUserSignedEIP712Message(action, isMainnet)
+	} else {
+		vaultAddress := ""
+		if request.VaultAddress != nil {
+			vaultAddress = *request.VaultAddress
+		}
+		srequest, err = buildL1EIP712Message(action, vaultAddress, request.Nonce, isMainnet)
+	}
+	if err != nil {
+		return nil, err
+	}
+	digest, _, err := apitypes.TypedDataAndHash(SignRequestToEIP712TypedData(srequest))
+	return digest, err
+}
+
+// VerifySigner recovers the signer of the exchange request and checks that it is one of expected,
+// e.g. the master account and its approved agents (see InfoAPI.GetExtraAgents).
+// The recovered address is returned with ErrSignerMismatch if it is not expected.
+func VerifySigner(request ExchangeRequest, isMainnet bool, expected ...common.Address) (common.Address, error) {
+	signer, err := RecoverSigner(request, isMainnet)
+	if err != nil {
+		return common.Address{}, err
+	}
+	for _, address := range expected {
+		if address == signer {
+			return signer, nil
+		}
+	}
+	return signer, fmt.Errorf("%w: %s", ErrSignerMismatch, signer.Hex())
+}
+
+// rsvToSignature converts the signature to the 65 bytes [R || S || V] format with V in {0, 1}.
+func rsvToSignature(sig RsvSignature) ([]byte, error) {
+	r, err := hexutil.Decode(sig.R)
+	if err != nil || len(r) > 32 {
+		return nil, fmt.Errorf("invalid signature r: %q", sig.R)
+	}
+	s, err := hexutil.Decode(sig.S)
+	if err != nil || len(s) > 32 {
+		return nil, fmt.Errorf("invalid signature s: %q", sig.S)
+	}
+	if sig.V != 27 && sig.V != 28 {
+		return nil, fmt.Errorf("invalid signature v: %d", sig.V)
+	}
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature[32-len(r):32], r)
+	copy(signature[64-len(s):64], s)
+	signature[64] = sig.V - 27
+	return signature, nil
+}
@@ -0,0 +1,67 @@
+package hyperliquid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRecoverSigner(t *testing.T) {
+	manager, _ := NewPKeyManager(testPrivateKey)
+	api := NewExchangeAPI(false)
+	api.SetKeyManager(manager)
+	api.SetVaultAddress("0x1719884eb866cb12b2287399b15f7db5e7d775ea")
+
+	order := OrderWiresToOrderAction([]OrderWire{{
+		Asset:     1,
+		IsBuy:     true,
+		LimitPx:   "2500",
+		SizePx:    "0.1",
+		OrderType: OrderTypeWire{Limit: &LimitOrderType{Tif: TifGtc}},
+		Cloid:     "0x00000000000000000000000000000001",
+	}}, GroupingNa)
+	orderRequest, err := api.buildL1Request(context.Background(), order)
+	if err != nil {
+		t.Fatalf("buildL1Request() error = %v", err)
+	}
+	withdraw := api.newWithdrawAction(manager.PublicAddressHex(), 10, 1700000000000)
+	v, r, s, err := api.SignWithdrawAction(withdraw)
+	if err != nil {
+		t.Fatalf("SignWithdrawAction() error = %v", err)
+	}
+	withdrawRequest := &ExchangeRequest{Action: withdraw, Nonce: withdraw.Time, Signature: ToTypedSig(r, s, v)}
+
+	for name, request := range map[string]*ExchangeRequest{"order": orderRequest, "withdraw": withdrawRequest} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := VerifySigner(*request, false, manager.PublicAddress())
+			if err != nil {
+				t.Errorf("VerifySigner() = %v, %v", signer, err)
+			}
+
+			// the gateway only sees the JSON body
+			data, _ := json.Marshal(request)
+			var received ExchangeRequest
+			json.Unmarshal(data, &received)
+			if signer, err := RecoverSigner(received, false); err != nil || signer != manager.PublicAddress() {
+				t.Errorf("RecoverSigner(JSON) = %v, %v, want %v", signer, err, manager.PublicAddress())
+			}
+
+			other := common.HexToAddress("0x1719884eb866cb12b2287399b15f7db5e7d775ea")
+			if _, err := VerifySigner(received, false, other); !errors.Is(err, ErrSignerMismatch) {
+				t.Errorf("VerifySigner() error = %v, want %v", err, ErrSignerMismatch)
+			}
+		})
+	}
+
+	if signer, _ := RecoverSigner(*orderRequest, true); signer == manager.PublicAddress() {
+		t.Errorf("RecoverSigner() on the other network = %v", signer)
+	}
+	tampered := *orderRequest
+	tampered.VaultAddress = nil
+	if signer, _ := RecoverSigner(tampered, false); signer == manager.PublicAddress() {
+		t.Errorf("RecoverSigner() ignores the vault address")
+	}
+}
@@ -15,6 +15,8 @@ var nonceCounter = time.Now().UnixMilli()
 // Hyperliquid uses timestamps in milliseconds for nonce
 // GetNonce returns a unique nonce that is always at least the current time in milliseconds.
 // It ensures thread-safe updates using atomic operations.
+// The sequence is shared by the whole process, ExchangeAPI uses a NonceManager
+// scoped per signer instead (see WithNonceManager).
 func GetNonce() uint64 {
 	now := time.Now().UnixMilli()
 	for {
@@ -1,6 +1,7 @@
 package hyperliquid
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -16,6 +17,7 @@ type IWebSocketAPI interface {
 
 	// Connection methods
 	Connect() error
+	ConnectWithContext(ctx context.Context) error
 	Disconnect() error
 	IsConnected() bool
 
@@ -25,13 +27,13 @@ type IWebSocketAPI interface {
 
 	// Post request methods
 	Post(requestType string, payload interface{}) (interface{}, error)
+	PostWithContext(ctx context.Context, requestType string, payload interface{}) (interface{}, error)
 }
 
 // WebSocketAPI is the default implementation of the IWebSocketAPI interface
 type WebSocketAPI struct {
 	Client
 	conn         *websocket.Conn
-	wsURL        string
 	connected    bool
 	handlers     map[string]func(data interface{})
 	postHandlers map[int]chan interface{}
@@ -39,25 +41,49 @@ type WebSocketAPI struct {
 	mu           sync.RWMutex
 	connMu       sync.Mutex
 	done         chan struct{}
+	assets       *AssetResolver
 }
 
 // NewWebSocketAPI returns a new instance of the WebSocketAPI struct
-func NewWebSocketAPI(isMainnet bool) *WebSocketAPI {
+// The opts are passed to NewClient, use WithWSURL and WithDialer to customise the connection.
+func NewWebSocketAPI(isMainnet bool, opts ...ClientOption) *WebSocketAPI {
 	api := WebSocketAPI{
-		Client:       *NewClient(isMainnet),
+		Client:       *NewClient(isMainnet, opts...),
 		connected:    false,
 		handlers:     make(map[string]func(data interface{})),
 		postHandlers: make(map[int]chan interface{}),
 		done:         make(chan struct{}),
 	}
+	return &api
+}
 
-	if isMainnet {
-		api.wsURL = MAINNET_WS_URL
-	} else {
-		api.wsURL = TESTNET_WS_URL
-	}
+// SetAssetResolver sets the resolver used to translate the coins of the subscriptions
+// to the market names sent by the server, e.g. "HYPE/USDC" or "HYPE" to "@107".
+// Share the resolver of an InfoAPI or ExchangeAPI so the metadata is loaded only once.
+// The subscriptions only use the metadata already loaded, by Load or a first request of the APIs.
+// Without a resolver the coins are sent as given.
+func (api *WebSocketAPI) SetAssetResolver(resolver *AssetResolver) {
+	api.assets = resolver
+}
 
-	return &api
+// AssetResolver returns the resolver set by SetAssetResolver, if any.
+func (api *WebSocketAPI) AssetResolver() *AssetResolver {
+	return api.assets
+}
+
+// coinName returns the market name of coin, perps being looked up first.
+// It never fetches the metadata so it does not block the subscriptions made from the callbacks:
+// the coins are sent as given until the resolver is loaded, and unknown coins are reported by the server.
+func (api *WebSocketAPI) coinName(coin string) string {
+	if api.assets == nil {
+		return coin
+	}
+	asset, ok := api.assets.resolveLoaded(coin)
+	if !ok {
+		api.debug("Coin %s not found in the loaded metadata", coin)
+		return coin
+	}
+	return asset.Name
 }
 
 // Endpoint implements the IAPIService interface
@@ -67,6 +93,12 @@ func (api *WebSocketAPI) Endpoint() string {
 
 // Connect establishes a connection to the WebSocket server
 func (api *WebSocketAPI) Connect() error {
+	return api.ConnectWithContext(context.Background())
+}
+
+// ConnectWithContext establishes a connection to the WebSocket server.
+// The context only bounds the handshake, not the lifetime of the connection.
+func (api *WebSocketAPI) ConnectWithContext(ctx context.Context) error {
 	api.connMu.Lock()
 	defer api.connMu.Unlock()
 
@@ -74,8 +106,8 @@ func (api *WebSocketAPI) Connect() error {
 		return nil
 	}
 
-	api.debug("connecting to %s", api.wsURL)
-	conn, _, err := websocket.DefaultDialer.Dial(api.wsURL, nil)
+	api.debug("connecting to %s", api.wsUrl)
+	conn, _, err := api.wsDialer.DialContext(ctx, api.wsUrl, nil)
 	if err != nil {
 		api.debug("error connecting to websocket: %s", err)
 		return err
@@ -194,8 +226,14 @@ func (api *WebSocketAPI) Unsubscribe(subscription Subscription) error {
 
 // Post sends a post request over WebSocket
 func (api *WebSocketAPI) Post(requestType string, payload interface{}) (interface{}, error) {
+	return api.PostWithContext(context.Background(), requestType, payload)
+}
+
+// PostWithContext sends a post request over WebSocket and waits for the response
+// until it arrives, the 15 second request timeout fires or ctx is done.
+func (api *WebSocketAPI) PostWithContext(ctx context.Context, requestType string, payload interface{}) (interface{}, error) {
 	if !api.IsConnected() {
-		err := api.Connect()
+		err := api.ConnectWithContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -237,6 +275,8 @@ func (api *WebSocketAPI) Post(requestType string, payload interface{}) (interfac
 		return response, nil
 	case <-time.After(15 * time.Second):
 		return nil, fmt.Errorf("request timeout")
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 }
 
@@ -311,7 +351,7 @@ func (api *WebSocketAPI) processMessage(message []byte) {
 				if errMsg == "" {
 					errMsg = "unknown error"
 				}
-				ch <- fmt.Errorf("%s", errMsg)
+				ch <- &ExchangeError{Message: errMsg}
 			} else {
 				payload := responseObj["payload"]
 				ch <- payload
@@ -414,7 +454,7 @@ func (api *WebSocketAPI) SubscribeToNotification(address string, callback func(d
 
 // SubscribeToCandle subscribes to candle updates for a specific coin and interval
 func (api *WebSocketAPI) SubscribeToCandle(coin string, interval string, callback func(data []Candle)) error {
-	return api.Subscribe(Subscription{Type: "candle", Coin: coin, Interval: interval}, func(data interface{}) {
+	return api.Subscribe(Subscription{Type: "candle", Coin: api.coinName(coin), Interval: interval}, func(data interface{}) {
 		var candles []Candle
 		jsonData, _ := json.Marshal(data)
 		json.Unmarshal(jsonData, &candles)
@@ -424,7 +464,7 @@ func (api *WebSocketAPI) SubscribeToCandle(coin string, interval string, callbac
 
 // SubscribeToL2Book subscribes to order book updates for a specific coin
 func (api *WebSocketAPI) SubscribeToL2Book(coin string, callback func(data WsBook)) error {
-	return api.Subscribe(Subscription{Type: "l2Book", Coin: coin}, func(data interface{}) {
+	return api.Subscribe(Subscription{Type: "l2Book", Coin: api.coinName(coin)}, func(data interface{}) {
 		var book WsBook
 		jsonData, _ := json.Marshal(data)
 		json.Unmarshal(jsonData, &book)
@@ -434,7 +474,7 @@ func (api *WebSocketAPI) SubscribeToL2Book(coin string, callback func(data WsBoo
 
 // SubscribeToTrades subscribes to trades for a specific coin
 func (api *WebSocketAPI) SubscribeToTrades(coin string, callback func(data []WsTrade)) error {
-	return api.Subscribe(Subscription{Type: "trades", Coin: coin}, func(data interface{}) {
+	return api.Subscribe(Subscription{Type: "trades", Coin: api.coinName(coin)}, func(data interface{}) {
 		var trades []WsTrade
 		jsonData, _ := json.Marshal(data)
 		json.Unmarshal(jsonData, &trades)
@@ -494,14 +534,14 @@ func (api *WebSocketAPI) SubscribeToUserNonFundingLedgerUpdates(address string,
 
 // SubscribeToActiveAssetCtx subscribes to active asset context for a specific coin
 func (api *WebSocketAPI) SubscribeToActiveAssetCtx(coin string, callback func(data interface{})) error {
-	return api.Subscribe(Subscription{Type: "activeAssetCtx", Coin: coin}, func(data interface{}) {
+	return api.Subscribe(Subscription{Type: "activeAssetCtx", Coin: api.coinName(coin)}, func(data interface{}) {
 		callback(data)
 	})
 }
 
 // SubscribeToActiveAssetData subscribes to active asset data for a specific user and coin
 func (api *WebSocketAPI) SubscribeToActiveAssetData(address string, coin string, callback func(data WsActiveAssetData)) error {
-	return api.Subscribe(Subscription{Type: "activeAssetData", User: address, Coin: coin}, func(data interface{}) {
+	return api.Subscribe(Subscription{Type: "activeAssetData", User: address, Coin: api.coinName(coin)}, func(data interface{}) {
 		var assetData WsActiveAssetData
 		jsonData, _ := json.Marshal(data)
 		json.Unmarshal(jsonData, &assetData)
@@ -531,7 +571,7 @@ func (api *WebSocketAPI) SubscribeToUserTwapHistory(address string, callback fun
 
 // SubscribeToBbo subscribes to BBO for a specific coin
 func (api *WebSocketAPI) SubscribeToBbo(coin string, callback func(data WsBbo)) error {
-	return api.Subscribe(Subscription{Type: "bbo", Coin: coin}, func(data interface{}) {
+	return api.Subscribe(Subscription{Type: "bbo", Coin: api.coinName(coin)}, func(data interface{}) {
 		var bbo WsBbo
 		jsonData, _ := json.Marshal(data)
 		json.Unmarshal(jsonData, &bbo)